@@ -0,0 +1,84 @@
+// Command pdconfig plans and applies declarative configurations of the
+// teams, schedules, escalation policies and services of a PagerDuty account.
+//
+// Usage:
+//
+//	pdconfig [-prune] [-auto-approve] plan|apply PATH...
+//
+// The paths are YAML or JSON files, or directories of them. The client speaks
+// the REST API v2, with the API key in $PAGERDUTY_API_KEY and the email
+// address of the user making the changes in $PAGERDUTY_FROM.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+	"github.com/hudl/go-pagerduty/pagerduty/config"
+)
+
+func main() {
+	prune := flag.Bool("prune", false, "delete the resources missing from the configuration")
+	autoApprove := flag.Bool("auto-approve", false, "apply without asking for confirmation")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: pdconfig [-prune] [-auto-approve] plan|apply PATH...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 || (flag.Arg(0) != "plan" && flag.Arg(0) != "apply") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), flag.Args()[1:], *prune, *autoApprove); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(command string, paths []string, prune, autoApprove bool) error {
+	key := os.Getenv("PAGERDUTY_API_KEY")
+	if key == "" {
+		return fmt.Errorf("PAGERDUTY_API_KEY is not set")
+	}
+
+	cfg, err := config.Load(paths...)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client := pagerduty.NewClientV2(nil, key, os.Getenv("PAGERDUTY_FROM"))
+
+	planner := config.NewPlanner(client)
+	planner.Prune = prune
+
+	plan, err := planner.Plan(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := plan.WriteTo(os.Stdout); err != nil {
+		return err
+	}
+
+	if command == "plan" || plan.Empty() {
+		return nil
+	}
+
+	if !autoApprove {
+		fmt.Print("\nApply these changes? Only 'yes' will be accepted: ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != "yes" {
+			return fmt.Errorf("apply cancelled")
+		}
+	}
+
+	fmt.Println()
+	return planner.Apply(ctx, plan, os.Stdout)
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"time"
 )
 
@@ -52,16 +53,63 @@ type alertListWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/alerts/list
 func (s *AlertsService) List(opts *AlertListOptions) ([]Alert, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *AlertsService) ListContext(ctx context.Context, opts *AlertListOptions) ([]Alert, *Response, error) {
+	if s.client.isV2() {
+		return nil, nil, errUnsupportedV2("AlertsService.List")
+	}
+
 	uri, err := addOptions("alerts", opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	alerts := new(alertListWrapper)
-	resp, err := s.client.Get(uri, alerts)
+	resp, err := s.client.GetContext(ctx, uri, alerts)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return alerts.Alerts, resp, err
 }
+
+// ListAll fetches every alert matching opts, following the pagination of List
+// until all of them or p.Max of them are collected.
+func (s *AlertsService) ListAll(opts *AlertListOptions, p *PaginationOptions) ([]Alert, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *AlertsService) ListAllContext(ctx context.Context, opts *AlertListOptions, p *PaginationOptions) ([]Alert, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every alert matching opts.
+func (s *AlertsService) Iter(opts *AlertListOptions) *Iterator[Alert] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *AlertsService) IterContext(ctx context.Context, opts *AlertListOptions) *Iterator[Alert] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the alerts matching opts.
+func (s *AlertsService) pager(opts *AlertListOptions) (ListOptions, pageFetcher[Alert]) {
+	var o AlertListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]Alert, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
@@ -0,0 +1,221 @@
+// Package backup saves the users, teams, schedules, escalation policies and
+// services of a PagerDuty account to a JSON snapshot, and restores the objects
+// of a snapshot missing from an account.
+//
+// Snapshots are deterministic: backing up an account which did not change
+// gives the same bytes, so that snapshots can be kept under version control
+// and compared. The objects are sorted by id, and the fields changing on their
+// own, such as the rendered entries of schedules or the incident counts of
+// services, are left out.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// Version is the version of the snapshots written by this package, increased
+// whenever their format changes incompatibly.
+const Version = 1
+
+// Snapshot is the state of an account at some point in time.
+type Snapshot struct {
+	Version int `json:"version"`
+
+	// The users along with their contact methods and notification rules.
+	Users []pagerduty.User `json:"users"`
+
+	// The teams along with their members, for clients speaking the REST API
+	// v2.
+	Teams []pagerduty.Team `json:"teams"`
+
+	// The schedules along with their layers.
+	Schedules []pagerduty.Schedule `json:"schedules"`
+
+	EscalationPolicies []pagerduty.EscalationPolicy `json:"escalation_policies"`
+
+	// The services along with their integrations, without their keys, for
+	// clients speaking the REST API v2.
+	Services []pagerduty.Service `json:"services"`
+}
+
+// Backup fetches every user, team, schedule, escalation policy and service of
+// the account of client, following the pagination of the lists. Teams and
+// services listed without an id are kept without their members and
+// integrations, and schedules listed without an id are left out.
+func Backup(ctx context.Context, client *pagerduty.Client) (*Snapshot, error) {
+	s := &Snapshot{Version: Version}
+
+	var err error
+	s.Users, err = client.Users.ListAllContext(ctx, &pagerduty.UserListOptions{
+		Include: []string{"contact_methods", "notification_rules"},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("backup: listing users: %w", err)
+	}
+
+	s.Teams, err = client.Teams.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("backup: listing teams: %w", err)
+	}
+	if client.APIVersion() == pagerduty.APIVersion2 {
+		for i, t := range s.Teams {
+			if t.ID == nil {
+				continue
+			}
+			s.Teams[i].Members, err = client.Teams.ListAllMembersContext(ctx, *t.ID, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("backup: listing members of team %s: %w", *t.ID, err)
+			}
+		}
+	}
+
+	schedules, err := client.Schedules.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("backup: listing schedules: %w", err)
+	}
+	for _, sch := range schedules {
+		// schedules without an id can neither be fetched nor restored
+		if sch.ID == nil {
+			continue
+		}
+
+		// listed schedules lack their layers
+		schedule, _, err := client.Schedules.GetContext(ctx, *sch.ID)
+		if err != nil {
+			return nil, fmt.Errorf("backup: fetching schedule %s: %w", *sch.ID, err)
+		}
+		if schedule != nil {
+			s.Schedules = append(s.Schedules, *schedule)
+		}
+	}
+
+	s.EscalationPolicies, err = client.EscalationPolicies.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("backup: listing escalation policies: %w", err)
+	}
+
+	s.Services, err = client.Services.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("backup: listing services: %w", err)
+	}
+	for _, svc := range s.Services {
+		for j, in := range svc.Integrations {
+			if svc.ID == nil || in.ID == nil {
+				continue
+			}
+
+			// listed services only reference their integrations
+			integration, _, err := client.Services.GetIntegrationContext(ctx, *svc.ID, *in.ID, nil)
+			if err != nil {
+				return nil, fmt.Errorf("backup: fetching integration %s of service %s: %w", *in.ID, *svc.ID, err)
+			}
+			if integration != nil {
+				svc.Integrations[j] = *integration
+			}
+		}
+	}
+
+	s.normalize()
+	return s, nil
+}
+
+// normalize sorts the objects of the snapshot by id, and clears the fields
+// changing on their own or duplicating other objects.
+func (s *Snapshot) normalize() {
+	sort.Slice(s.Users, func(i, j int) bool { return id(s.Users[i].ID) < id(s.Users[j].ID) })
+	for i := range s.Users {
+		u := &s.Users[i]
+		sort.Slice(u.ContactMethods, func(i, j int) bool { return id(u.ContactMethods[i].ID) < id(u.ContactMethods[j].ID) })
+		sort.Slice(u.NotificationRules, func(i, j int) bool { return id(u.NotificationRules[i].ID) < id(u.NotificationRules[j].ID) })
+	}
+
+	sort.Slice(s.Teams, func(i, j int) bool { return id(s.Teams[i].ID) < id(s.Teams[j].ID) })
+	for i := range s.Teams {
+		t := &s.Teams[i]
+		sort.Slice(t.Members, func(i, j int) bool { return memberID(t.Members[i]) < memberID(t.Members[j]) })
+	}
+
+	sort.Slice(s.Schedules, func(i, j int) bool { return id(s.Schedules[i].ID) < id(s.Schedules[j].ID) })
+	for i := range s.Schedules {
+		sch := &s.Schedules[i]
+		sch.Today = nil
+		sch.FinalSchedule = nil
+		sch.OverridesSubschedule = nil
+		sch.EscalationPolicies = nil
+		for j := range sch.ScheduleLayers {
+			sch.ScheduleLayers[j].RenderedScheduleEntries = nil
+			sch.ScheduleLayers[j].RenderedCoveragePercentage = nil
+		}
+	}
+
+	sort.Slice(s.EscalationPolicies, func(i, j int) bool { return id(s.EscalationPolicies[i].ID) < id(s.EscalationPolicies[j].ID) })
+	for i := range s.EscalationPolicies {
+		s.EscalationPolicies[i].Services = nil
+	}
+
+	sort.Slice(s.Services, func(i, j int) bool { return id(s.Services[i].ID) < id(s.Services[j].ID) })
+	for i := range s.Services {
+		svc := &s.Services[i]
+		svc.LastIncidentTimestamp = nil
+		svc.IncidentCounts = nil
+
+		// restored integrations get new keys
+		sort.Slice(svc.Integrations, func(i, j int) bool { return id(svc.Integrations[i].ID) < id(svc.Integrations[j].ID) })
+		for j := range svc.Integrations {
+			svc.Integrations[j].IntegrationKey = nil
+			svc.Integrations[j].Service = nil
+			svc.Integrations[j].CreatedAt = nil
+		}
+
+		// the other statuses reflect the open incidents of the service
+		if svc.Status != nil && *svc.Status != "disabled" {
+			svc.Status = nil
+		}
+	}
+}
+
+// WriteTo writes the snapshot to w as indented JSON.
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("backup: encoding snapshot: %w", err)
+	}
+
+	n, err := io.Copy(w, bytes.NewReader(append(data, '\n')))
+	return n, err
+}
+
+// Read reads a snapshot written by WriteTo, checking its version is supported.
+func Read(r io.Reader) (*Snapshot, error) {
+	s := new(Snapshot)
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, fmt.Errorf("backup: decoding snapshot: %w", err)
+	}
+
+	if s.Version < 1 || s.Version > Version {
+		return nil, fmt.Errorf("backup: unsupported snapshot version %d", s.Version)
+	}
+
+	return s, nil
+}
+
+func id(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func memberID(m pagerduty.TeamMember) string {
+	if m.User == nil {
+		return ""
+	}
+	return id(m.User.ID)
+}
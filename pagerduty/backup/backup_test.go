@@ -0,0 +1,256 @@
+package backup_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/backup"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	usersJSON = `{"more": false, "users": [
+		{"id": "PBOB001", "name": "Bob", "email": "bob@example.com", "contact_methods": [
+			{"id": "PCM0002", "type": "phone_contact_method", "address": "5555550100", "country_code": 1},
+			{"id": "PCM0001", "type": "email_contact_method", "address": "bob@example.com"}
+		]},
+		{"id": "PALICE1", "name": "Alice", "email": "alice@example.com"}
+	]}`
+	teamsJSON = `{"more": false, "teams": [
+		{"id": "PTEAM01", "name": "Platform", "description": "Platform team"}
+	]}`
+	membersJSON = `{"more": false, "members": [
+		{"user": {"id": "PBOB001", "type": "user_reference"}, "role": "manager"},
+		{"user": {"id": "PALICE1", "type": "user_reference"}, "role": "responder"}
+	]}`
+	schedulesJSON = `{"more": false, "schedules": [
+		{"id": "PSCHED1", "name": "Primary", "time_zone": "UTC"}
+	]}`
+	scheduleJSON = `{"schedule": {
+		"id": "PSCHED1",
+		"name": "Primary",
+		"time_zone": "UTC",
+		"today": "2015-11-09",
+		"schedule_layers": [{
+			"id": "PLAYER1",
+			"name": "Weekly",
+			"start": "2015-11-07T01:00:00Z",
+			"rotation_virtual_start": "2015-11-07T01:00:00Z",
+			"rotation_turn_length_seconds": 604800,
+			"rendered_coverage_percentage": 100,
+			"rendered_schedule_entries": [{"start": "2015-11-09T00:00:00Z", "end": "2015-11-10T00:00:00Z", "user": {"id": "PALICE1"}}],
+			"users": [{"user": {"id": "PALICE1", "type": "user_reference"}}, {"user": {"id": "PBOB001", "type": "user_reference"}}]
+		}],
+		"final_schedule": {"name": "Final Schedule", "rendered_coverage_percentage": 100},
+		"escalation_policies": [{"id": "PEP0001", "type": "escalation_policy_reference"}]
+	}}`
+	policiesJSON = `{"more": false, "escalation_policies": [{
+		"id": "PEP0001",
+		"name": "Default",
+		"escalation_rules": [{
+			"id": "PRULE01",
+			"escalation_delay_in_minutes": 30,
+			"targets": [{"id": "PSCHED1", "type": "schedule_reference"}, {"id": "PBOB001", "type": "user_reference"}]
+		}],
+		"services": [{"id": "PSVC001", "type": "service_reference"}]
+	}]}`
+	servicesJSON = `{"more": false, "services": [
+		{
+			"id": "PSVC002",
+			"name": "Legacy",
+			"status": "disabled",
+			"escalation_policy": {"id": "PEP0001", "type": "escalation_policy_reference"}
+		},
+		{
+			"id": "PSVC001",
+			"name": "API",
+			"status": "critical",
+			"last_incident_timestamp": "2015-11-09T10:00:00Z",
+			"incident_counts": {"triggered": 1, "total": 1},
+			"escalation_policy": {"id": "PEP0001", "type": "escalation_policy_reference"}
+		}
+	]}`
+)
+
+var _ = Describe("Backup", func() {
+	var (
+		server *ghttp.Server
+		client *pagerduty.Client
+
+		snapshot *Snapshot
+		err      error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = pagerduty.NewClientV2(nil, "super-secret-key", "")
+		client.BaseURL, _ = url.Parse(server.URL())
+
+		server.RouteToHandler("GET", "/users", ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/users", "include=contact_methods&include=notification_rules"),
+			ghttp.RespondWith(http.StatusOK, usersJSON),
+		))
+		for path, body := range map[string]string{
+			"/teams":                 teamsJSON,
+			"/teams/PTEAM01/members": membersJSON,
+			"/schedules":             schedulesJSON,
+			"/schedules/PSCHED1":     scheduleJSON,
+			"/escalation_policies":   policiesJSON,
+			"/services":              servicesJSON,
+		} {
+			server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, body))
+		}
+	})
+
+	AfterEach(func() { server.Close() })
+
+	JustBeforeEach(func() {
+		snapshot, err = Backup(context.Background(), client)
+	})
+
+	It("should not return an error", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return a snapshot of the current version", func() {
+		Expect(snapshot.Version).To(Equal(Version))
+	})
+
+	It("should sort the objects by id", func() {
+		Expect(*snapshot.Users[0].ID).To(Equal("PALICE1"))
+		Expect(*snapshot.Users[1].ID).To(Equal("PBOB001"))
+		Expect(*snapshot.Users[1].ContactMethods[0].ID).To(Equal("PCM0001"))
+		Expect(*snapshot.Teams[0].Members[0].User.ID).To(Equal("PALICE1"))
+		Expect(*snapshot.Services[0].ID).To(Equal("PSVC001"))
+	})
+
+	It("should include the members of the teams", func() {
+		Expect(snapshot.Teams[0].Members).To(HaveLen(2))
+		Expect(*snapshot.Teams[0].Members[1].Role).To(Equal("manager"))
+	})
+
+	It("should include the layers of the schedules without their rendering", func() {
+		schedule := snapshot.Schedules[0]
+		Expect(schedule.ScheduleLayers).To(HaveLen(1))
+		Expect(schedule.ScheduleLayers[0].Users).To(HaveLen(2))
+		Expect(schedule.ScheduleLayers[0].RenderedScheduleEntries).To(BeNil())
+		Expect(schedule.ScheduleLayers[0].RenderedCoveragePercentage).To(BeNil())
+		Expect(schedule.FinalSchedule).To(BeNil())
+		Expect(schedule.Today).To(BeNil())
+		Expect(schedule.EscalationPolicies).To(BeNil())
+	})
+
+	It("should leave out the incident related fields of the services", func() {
+		Expect(snapshot.Services[0].Status).To(BeNil())
+		Expect(snapshot.Services[0].IncidentCounts).To(BeNil())
+		Expect(snapshot.Services[0].LastIncidentTimestamp).To(BeNil())
+		Expect(*snapshot.Services[1].Status).To(Equal("disabled"))
+		Expect(snapshot.EscalationPolicies[0].Services).To(BeNil())
+	})
+
+	Context("with service integrations", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/services", ghttp.RespondWith(http.StatusOK, `{"more": false, "services": [{
+				"id": "PSVC001",
+				"name": "API",
+				"integrations": [{"id": "PINT001", "type": "generic_events_api_inbound_integration_reference"}]
+			}]}`))
+			server.RouteToHandler("GET", "/services/PSVC001/integrations/PINT001", ghttp.RespondWith(http.StatusOK, `{"integration": {
+				"id": "PINT001",
+				"type": "generic_events_api_inbound_integration",
+				"name": "Monitoring",
+				"created_at": "2015-11-01T10:00:00Z",
+				"integration_key": "8e3fa8e9dd4a4b8ea7bd6a4db7fdbde0",
+				"service": {"id": "PSVC001", "type": "service_reference"},
+				"vendor": {"id": "PVEND01", "type": "vendor_reference"}
+			}}`))
+		})
+
+		It("should include the integrations without their keys", func() {
+			integrations := snapshot.Services[0].Integrations
+			Expect(integrations).To(HaveLen(1))
+			Expect(*integrations[0].Name).To(Equal("Monitoring"))
+			Expect(*integrations[0].Vendor.ID).To(Equal("PVEND01"))
+			Expect(integrations[0].IntegrationKey).To(BeNil())
+			Expect(integrations[0].Service).To(BeNil())
+			Expect(integrations[0].CreatedAt).To(BeNil())
+		})
+	})
+
+	Context("with listed objects without an id", func() {
+		BeforeEach(func() {
+			for path, body := range map[string]string{
+				"/teams":     `{"more": false, "teams": [{"name": "Orphans"}]}`,
+				"/schedules": `{"more": false, "schedules": [{"name": "Orphans"}]}`,
+				"/services":  `{"more": false, "services": [{"name": "Orphans", "integrations": [{"id": "PINT001"}]}]}`,
+			} {
+				server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, body))
+			}
+		})
+
+		It("should not fetch their details", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshot.Teams).To(HaveLen(1))
+			Expect(snapshot.Schedules).To(BeEmpty())
+			Expect(snapshot.Services).To(HaveLen(1))
+		})
+	})
+
+	Describe("writing", func() {
+		var buf bytes.Buffer
+
+		JustBeforeEach(func() {
+			buf.Reset()
+			_, err = snapshot.WriteTo(&buf)
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should write indented JSON", func() {
+			Expect(buf.String()).To(HavePrefix("{\n  \"version\": 1,\n  \"users\": [\n"))
+			Expect(buf.String()).To(HaveSuffix("}\n"))
+		})
+
+		It("should be deterministic", func() {
+			again, err := Backup(context.Background(), client)
+			Expect(err).NotTo(HaveOccurred())
+
+			var other bytes.Buffer
+			again.WriteTo(&other)
+			Expect(other.String()).To(Equal(buf.String()))
+		})
+
+		It("should be read back", func() {
+			read, err := Read(&buf)
+			Expect(err).NotTo(HaveOccurred())
+
+			var again bytes.Buffer
+			read.WriteTo(&again)
+
+			var original bytes.Buffer
+			snapshot.WriteTo(&original)
+			Expect(again.String()).To(Equal(original.String()))
+		})
+	})
+
+	Describe("reading", func() {
+		It("should reject snapshots of unknown versions", func() {
+			_, err := Read(strings.NewReader(`{"version": 2}`))
+			Expect(err).To(MatchError("backup: unsupported snapshot version 2"))
+		})
+
+		It("should reject documents without a version", func() {
+			_, err := Read(strings.NewReader(`{"users": []}`))
+			Expect(err).To(MatchError("backup: unsupported snapshot version 0"))
+		})
+	})
+})
@@ -0,0 +1,546 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// ChangeType is how an object differs between two snapshots.
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeChanged ChangeType = "changed"
+)
+
+var changeSigns = map[ChangeType]string{
+	ChangeAdded:   "+",
+	ChangeRemoved: "-",
+	ChangeChanged: "~",
+}
+
+// FieldChange is the change of a field of an object, with its path, e.g.
+// 'schedule_layers[0].users', and its values before and after, nil when the
+// field is unset.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// Change is an object added, removed or changed between two snapshots.
+type Change struct {
+	Kind string     `json:"kind"`
+	Type ChangeType `json:"change"`
+
+	// The name of the object, or the email address of users, and its id in
+	// the snapshot it is part of, the later one unless it was removed.
+	Name string `json:"name"`
+	ID   string `json:"id"`
+
+	// The changed fields, for changed objects.
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %q (%s)", changeSigns[c.Type], kindName(c.Kind), c.Name, c.ID)
+}
+
+// Diff is the list of differences between two snapshots, by kind of object in
+// restore order, then by name.
+type Diff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty reports whether the snapshots are alike.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// WriteText writes the differences to w for humans, one object per line
+// followed by its changed fields, and a summary.
+func (d *Diff) WriteText(w io.Writer) error {
+	var buf bytes.Buffer
+	if d.Empty() {
+		fmt.Fprintln(&buf, "No differences.")
+	}
+
+	counts := make(map[ChangeType]int)
+	for _, c := range d.Changes {
+		counts[c.Type]++
+
+		fmt.Fprintln(&buf, c)
+		for _, f := range c.Fields {
+			fmt.Fprintf(&buf, "    %s: %s -> %s\n", f.Field, formatValue(f.From), formatValue(f.To))
+		}
+	}
+
+	if !d.Empty() {
+		fmt.Fprintf(&buf, "\n%d added, %d removed, %d changed.\n",
+			counts[ChangeAdded], counts[ChangeRemoved], counts[ChangeChanged])
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteJSON writes the differences to w as indented JSON.
+func (d *Diff) WriteJSON(w io.Writer) error {
+	out := *d
+	if out.Changes == nil {
+		out.Changes = []Change{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&out)
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// CompareAccount compares a snapshot with the current state of the account of
+// client.
+func CompareAccount(ctx context.Context, client *pagerduty.Client, snapshot *Snapshot) (*Diff, error) {
+	live, err := Backup(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compare(snapshot, live), nil
+}
+
+// Compare returns the differences between the snapshots from and to.
+//
+// The objects of both snapshots are matched by id or, failing that, by name,
+// or email address for users, so that a snapshot can be compared with an
+// account it was restored to. The references of the objects of from to the
+// matched objects are translated to the ids of to before being compared.
+func Compare(from, to *Snapshot) *Diff {
+	c := &comparison{ids: make(map[string]string)}
+
+	users := c.match(KindUser, len(from.Users), len(to.Users),
+		func(i int) (*string, string) { return from.Users[i].ID, strings.ToLower(id(from.Users[i].Email)) },
+		func(i int) (*string, string) { return to.Users[i].ID, strings.ToLower(id(to.Users[i].Email)) })
+	teams := c.match(KindTeam, len(from.Teams), len(to.Teams),
+		func(i int) (*string, string) { return from.Teams[i].ID, id(from.Teams[i].Name) },
+		func(i int) (*string, string) { return to.Teams[i].ID, id(to.Teams[i].Name) })
+	schedules := c.match(KindSchedule, len(from.Schedules), len(to.Schedules),
+		func(i int) (*string, string) { return from.Schedules[i].ID, id(from.Schedules[i].Name) },
+		func(i int) (*string, string) { return to.Schedules[i].ID, id(to.Schedules[i].Name) })
+	policies := c.match(KindEscalationPolicy, len(from.EscalationPolicies), len(to.EscalationPolicies),
+		func(i int) (*string, string) {
+			return from.EscalationPolicies[i].ID, id(from.EscalationPolicies[i].Name)
+		},
+		func(i int) (*string, string) { return to.EscalationPolicies[i].ID, id(to.EscalationPolicies[i].Name) })
+	services := c.match(KindService, len(from.Services), len(to.Services),
+		func(i int) (*string, string) { return from.Services[i].ID, id(from.Services[i].Name) },
+		func(i int) (*string, string) { return to.Services[i].ID, id(to.Services[i].Name) })
+
+	for _, m := range users {
+		c.fields(m, func(f *fields) { c.compareUsers(f, &from.Users[m.from], &to.Users[m.to]) })
+	}
+	for _, m := range teams {
+		c.fields(m, func(f *fields) { c.compareTeams(f, &from.Teams[m.from], &to.Teams[m.to]) })
+	}
+	for _, m := range schedules {
+		c.fields(m, func(f *fields) { c.compareSchedules(f, &from.Schedules[m.from], &to.Schedules[m.to]) })
+	}
+	for _, m := range policies {
+		c.fields(m, func(f *fields) {
+			c.compareEscalationPolicies(f, &from.EscalationPolicies[m.from], &to.EscalationPolicies[m.to])
+		})
+	}
+	for _, m := range services {
+		c.fields(m, func(f *fields) { c.compareServices(f, &from.Services[m.from], &to.Services[m.to]) })
+	}
+
+	kinds := map[string]int{KindUser: 0, KindTeam: 1, KindSchedule: 2, KindEscalationPolicy: 3, KindService: 4}
+	sort.SliceStable(c.diff.Changes, func(i, j int) bool {
+		a, b := c.diff.Changes[i], c.diff.Changes[j]
+		if a.Kind != b.Kind {
+			return kinds[a.Kind] < kinds[b.Kind]
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	return &c.diff
+}
+
+// comparison holds the state of a comparison.
+type comparison struct {
+	diff Diff
+
+	// the ids of to of the matched objects, by their ids in from
+	ids map[string]string
+}
+
+// match is a pair of matched objects, by index in from and to, along with
+// their kind, name and id in to.
+type match struct {
+	from, to int
+	kind     string
+	name, id string
+}
+
+// match matches the n objects of a kind of from with the m ones of to,
+// records the added and removed ones, and returns the pairs.
+func (c *comparison) match(kind string, n, m int, from, to func(i int) (*string, string)) []match {
+	byID := make(map[string]int)
+	byName := make(map[string]int)
+	for i := 0; i < m; i++ {
+		id, name := to(i)
+		if id != nil {
+			byID[*id] = i
+		}
+		if _, ok := byName[name]; !ok && name != "" {
+			byName[name] = i
+		}
+	}
+
+	var matches []match
+	matched := make(map[int]bool)
+	pending := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		fromID, _ := from(i)
+		if j, ok := byID[id(fromID)]; ok && fromID != nil {
+			matches = append(matches, c.pair(kind, i, j, from, to))
+			matched[j] = true
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	// the objects missing by id are matched by name in a second pass, so that
+	// the objects matched by id are not taken
+	for _, i := range pending {
+		_, name := from(i)
+		if j, ok := byName[name]; ok && !matched[j] {
+			matches = append(matches, c.pair(kind, i, j, from, to))
+			matched[j] = true
+			continue
+		}
+
+		fromID, _ := from(i)
+		c.diff.Changes = append(c.diff.Changes, Change{Kind: kind, Type: ChangeRemoved, Name: name, ID: id(fromID)})
+	}
+
+	for j := 0; j < m; j++ {
+		if !matched[j] {
+			toID, name := to(j)
+			c.diff.Changes = append(c.diff.Changes, Change{Kind: kind, Type: ChangeAdded, Name: name, ID: id(toID)})
+		}
+	}
+
+	return matches
+}
+
+func (c *comparison) pair(kind string, i, j int, from, to func(i int) (*string, string)) match {
+	fromID, _ := from(i)
+	toID, name := to(j)
+	c.ids[id(fromID)] = id(toID)
+
+	return match{from: i, to: j, kind: kind, name: name, id: id(toID)}
+}
+
+// fields records the matched objects whose fields, as compared by compare,
+// changed.
+func (c *comparison) fields(m match, compare func(f *fields)) {
+	f := new(fields)
+	compare(f)
+
+	if len(f.changes) > 0 {
+		c.diff.Changes = append(c.diff.Changes, Change{Kind: m.kind, Type: ChangeChanged, Name: m.name, ID: m.id, Fields: f.changes})
+	}
+}
+
+// remap returns the id in to of the object with the given id in from.
+func (c *comparison) remap(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	if id, ok := c.ids[*s]; ok {
+		return id
+	}
+	return *s
+}
+
+// fields collects the changed fields of an object.
+type fields struct {
+	changes []FieldChange
+}
+
+func (f *fields) compare(field string, from, to interface{}) {
+	if !reflect.DeepEqual(from, to) {
+		f.changes = append(f.changes, FieldChange{Field: field, From: from, To: to})
+	}
+}
+
+func value[T any](p *T) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func dateValue(d *pagerduty.Date) interface{} {
+	if d == nil {
+		return nil
+	}
+	return timeValue(d.Time)
+}
+
+func timeZoneValue(tz *pagerduty.TimeZone) interface{} {
+	if tz == nil || tz.Location == nil {
+		return nil
+	}
+	return tz.Location.String()
+}
+
+// list returns the non-empty values as a list, or nil when there are none, so
+// that missing and empty lists compare alike.
+func list(values []string) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func (c *comparison) compareUsers(f *fields, from, to *pagerduty.User) {
+	f.compare("name", value(from.Name), value(to.Name))
+	f.compare("email", value(from.Email), value(to.Email))
+	f.compare("role", value(from.Role), value(to.Role))
+	f.compare("job_title", value(from.JobTitle), value(to.JobTitle))
+	f.compare("time_zone", timeZoneValue(from.TimeZone), timeZoneValue(to.TimeZone))
+	f.compare("color", value(from.Color), value(to.Color))
+	f.compare("contact_methods", contactMethods(from.ContactMethods), contactMethods(to.ContactMethods))
+}
+
+// contactMethods describes the contact methods of a user by type and address.
+func contactMethods(methods []pagerduty.ContactMethod) interface{} {
+	var values []string
+	for _, m := range methods {
+		address := id(m.Address)
+		if address == "" {
+			// REST API v1 contact methods
+			address = id(m.Email) + id(m.PhoneNumber)
+		}
+		values = append(values, strings.TrimSuffix(id(m.Type), "_contact_method")+" "+address)
+	}
+	sort.Strings(values)
+
+	return list(values)
+}
+
+func (c *comparison) compareTeams(f *fields, from, to *pagerduty.Team) {
+	f.compare("name", value(from.Name), value(to.Name))
+	f.compare("description", value(from.Description), value(to.Description))
+
+	members := func(members []pagerduty.TeamMember, remap bool) interface{} {
+		var values []string
+		for _, m := range members {
+			if m.User == nil || m.User.ID == nil {
+				continue
+			}
+
+			userID := *m.User.ID
+			if remap {
+				userID = c.remap(m.User.ID).(string)
+			}
+			values = append(values, userID+" "+id(m.Role))
+		}
+		sort.Strings(values)
+
+		return list(values)
+	}
+	f.compare("members", members(from.Members, true), members(to.Members, false))
+}
+
+func (c *comparison) compareSchedules(f *fields, from, to *pagerduty.Schedule) {
+	f.compare("name", value(from.Name), value(to.Name))
+	f.compare("time_zone", timeZoneValue(from.TimeZone), timeZoneValue(to.TimeZone))
+
+	// layers are matched by id, then by position
+	toLayers := make(map[string]int)
+	for j, l := range to.ScheduleLayers {
+		toLayers[id(l.ID)] = j
+	}
+
+	matched := make(map[int]bool)
+	for i := range from.ScheduleLayers {
+		fl := &from.ScheduleLayers[i]
+
+		j, ok := toLayers[id(fl.ID)]
+		if !ok || fl.ID == nil {
+			j = i
+		}
+		if j >= len(to.ScheduleLayers) || matched[j] {
+			f.compare(fmt.Sprintf("schedule_layers[%d]", i), value(fl.Name), nil)
+			continue
+		}
+		matched[j] = true
+
+		c.compareLayers(f, fmt.Sprintf("schedule_layers[%d]", j), fl, &to.ScheduleLayers[j])
+	}
+
+	for j := range to.ScheduleLayers {
+		if !matched[j] {
+			f.compare(fmt.Sprintf("schedule_layers[%d]", j), nil, value(to.ScheduleLayers[j].Name))
+		}
+	}
+}
+
+func (c *comparison) compareLayers(f *fields, path string, from, to *pagerduty.ScheduleLayer) {
+	f.compare(path+".name", value(from.Name), value(to.Name))
+	f.compare(path+".priority", value(from.Priority), value(to.Priority))
+	f.compare(path+".start", dateValue(from.Start), dateValue(to.Start))
+	f.compare(path+".end", dateValue(from.End), dateValue(to.End))
+	f.compare(path+".rotation_virtual_start", timeValue(from.RotationVirtualStart), timeValue(to.RotationVirtualStart))
+	f.compare(path+".rotation_turn_length_seconds", value(from.RotationTurnLengthSeconds), value(to.RotationTurnLengthSeconds))
+	f.compare(path+".restrictions", restrictions(from.Restrictions), restrictions(to.Restrictions))
+
+	// the order of the users is the order of the rotation
+	users := func(users []pagerduty.User, remap bool) interface{} {
+		var values []string
+		for _, u := range users {
+			if remap {
+				values = append(values, fmt.Sprint(c.remap(u.ID)))
+			} else {
+				values = append(values, id(u.ID))
+			}
+		}
+		return list(values)
+	}
+	f.compare(path+".users", users(from.Users, true), users(to.Users, false))
+}
+
+// restrictions describes restrictions, e.g. 'weekly from Monday 09:00:00 for
+// 8h0m0s'.
+func restrictions(restrictions []pagerduty.Restriction) interface{} {
+	var values []string
+	for _, r := range restrictions {
+		var start string
+		if r.StartTimeOfDay != nil {
+			start = r.StartTimeOfDay.String()
+		}
+		if r.StartDayOfWeek != nil {
+			start = time.Weekday(*r.StartDayOfWeek%7).String() + " " + start
+		}
+
+		var d time.Duration
+		if r.DurationSeconds != nil {
+			d = time.Duration(*r.DurationSeconds) * time.Second
+		}
+
+		values = append(values, fmt.Sprintf("%s from %s for %s", strings.TrimSuffix(id(r.Type), "_restriction"), start, d))
+	}
+
+	return list(values)
+}
+
+func (c *comparison) compareEscalationPolicies(f *fields, from, to *pagerduty.EscalationPolicy) {
+	f.compare("name", value(from.Name), value(to.Name))
+	f.compare("num_loops", value(from.NumLoops), value(to.NumLoops))
+
+	n := len(from.EscalationRules)
+	if len(to.EscalationRules) > n {
+		n = len(to.EscalationRules)
+	}
+
+	for i := 0; i < n; i++ {
+		path := fmt.Sprintf("escalation_rules[%d]", i)
+
+		switch {
+		case i >= len(from.EscalationRules):
+			f.compare(path, nil, c.rule(&to.EscalationRules[i], false))
+		case i >= len(to.EscalationRules):
+			f.compare(path, c.rule(&from.EscalationRules[i], true), nil)
+		default:
+			fr, tr := &from.EscalationRules[i], &to.EscalationRules[i]
+			f.compare(path+".escalation_delay_in_minutes", value(fr.EscalationDelayInMinutes), value(tr.EscalationDelayInMinutes))
+			f.compare(path+".targets", c.targets(fr.Targets, true), c.targets(tr.Targets, false))
+		}
+	}
+}
+
+// rule describes a whole escalation rule added or removed.
+func (c *comparison) rule(r *pagerduty.EscalationRule, remap bool) interface{} {
+	return map[string]interface{}{
+		"escalation_delay_in_minutes": value(r.EscalationDelayInMinutes),
+		"targets":                     c.targets(r.Targets, remap),
+	}
+}
+
+// targets describes the targets of an escalation rule by type and id, e.g.
+// 'schedule PI7DH85'.
+func (c *comparison) targets(targets []pagerduty.Target, remap bool) interface{} {
+	var values []string
+	for _, t := range targets {
+		var targetType string
+		if t.Type != nil {
+			targetType = strings.TrimSuffix(*t.Type, "_reference")
+		}
+
+		targetID := id(t.ID)
+		if remap {
+			targetID = fmt.Sprint(c.remap(t.ID))
+		}
+		values = append(values, targetType+" "+targetID)
+	}
+
+	return list(values)
+}
+
+func (c *comparison) compareServices(f *fields, from, to *pagerduty.Service) {
+	f.compare("name", value(from.Name), value(to.Name))
+	f.compare("description", value(from.Description), value(to.Description))
+	f.compare("status", value(from.Status), value(to.Status))
+	f.compare("service_type", value(from.Type), value(to.Type))
+	f.compare("auto_resolve_timeout", value(from.AutoResolveTimeout), value(to.AutoResolveTimeout))
+	f.compare("acknowledgement_timeout", value(from.AcknowledgementTimeout), value(to.AcknowledgementTimeout))
+	f.compare("email_incident_creation", value(from.EmailIncidentCreation), value(to.EmailIncidentCreation))
+	f.compare("email_filter_mode", value(from.EmailFilterMode), value(to.EmailFilterMode))
+	f.compare("severity_filter", value(from.SeverityFilter), value(to.SeverityFilter))
+
+	var fromPolicy, toPolicy interface{}
+	if from.EscalationPolicy != nil {
+		fromPolicy = c.remap(from.EscalationPolicy.ID)
+	}
+	if to.EscalationPolicy != nil {
+		toPolicy = value(to.EscalationPolicy.ID)
+	}
+	f.compare("escalation_policy", fromPolicy, toPolicy)
+}
+
+func kindName(kind string) string {
+	return strings.ReplaceAll(kind, "_", " ")
+}
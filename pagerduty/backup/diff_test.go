@@ -0,0 +1,257 @@
+package backup_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/backup"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// laterJSON is the account of snapshotJSON restored under other ids, then
+// edited.
+const laterJSON = `{
+  "version": 1,
+  "users": [
+    {"id": "PALICE1", "name": "Alice", "email": "alice@example.com"},
+    {"id": "PBOB002", "name": "Bob", "email": "bob@example.com", "role": "user", "type": "user"},
+    {"id": "PCAROL1", "name": "Carol", "email": "carol@example.com"}
+  ],
+  "teams": [
+    {"id": "PTEAM02", "name": "Platform", "members": [
+      {"user": {"id": "PALICE1"}, "role": "responder"},
+      {"user": {"id": "PBOB002"}, "role": "manager"}
+    ]}
+  ],
+  "schedules": [
+    {"id": "PSCHED2", "name": "Primary", "time_zone": "UTC", "schedule_layers": [
+      {"id": "PLAYER2", "name": "Weekly", "start": "2015-11-07T01:00:00Z", "rotation_turn_length_seconds": 86400,
+       "users": [{"user": {"id": "PBOB002"}}, {"user": {"id": "PALICE1"}}]}
+    ]}
+  ],
+  "escalation_policies": [
+    {"id": "PEP0002", "name": "Default", "escalation_rules": [
+      {"id": "PRULE02", "escalation_delay_in_minutes": 15, "targets": [
+        {"id": "PSCHED2", "type": "schedule_reference"},
+        {"id": "PBOB002", "type": "user_reference"}
+      ]},
+      {"id": "PRULE03", "escalation_delay_in_minutes": 5, "targets": [
+        {"id": "PALICE1", "type": "user_reference"}
+      ]}
+    ]}
+  ],
+  "services": [
+    {"id": "PSVC002", "name": "API", "acknowledgement_timeout": 1800, "escalation_policy": {"id": "PEP0002"}},
+    {"id": "PSVC003", "name": "Web", "escalation_policy": {"id": "PEP0002"}}
+  ]
+}`
+
+var _ = Describe("Compare", func() {
+	var (
+		from, to *Snapshot
+		diff     *Diff
+	)
+
+	BeforeEach(func() {
+		var err error
+		from, err = Read(strings.NewReader(snapshotJSON))
+		Expect(err).NotTo(HaveOccurred())
+		to, err = Read(strings.NewReader(laterJSON))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		diff = Compare(from, to)
+	})
+
+	It("should report the differences by kind, then by name", func() {
+		var changes []string
+		for _, c := range diff.Changes {
+			changes = append(changes, c.String())
+		}
+
+		Expect(changes).To(Equal([]string{
+			`+ user "carol@example.com" (PCAROL1)`,
+			`~ schedule "Primary" (PSCHED2)`,
+			`~ escalation policy "Default" (PEP0002)`,
+			`~ service "API" (PSVC002)`,
+			`+ service "Web" (PSVC003)`,
+		}))
+	})
+
+	It("should report the changed users and rotation of the schedule layers", func() {
+		Expect(diff.Changes[1].Type).To(Equal(ChangeChanged))
+		Expect(diff.Changes[1].Fields).To(Equal([]FieldChange{
+			{Field: "schedule_layers[0].rotation_turn_length_seconds", From: nil, To: 86400},
+			{Field: "schedule_layers[0].users", From: []string{"PALICE1"}, To: []string{"PBOB002", "PALICE1"}},
+		}))
+	})
+
+	It("should report the changed escalation rules", func() {
+		Expect(diff.Changes[2].Fields).To(Equal([]FieldChange{
+			{Field: "escalation_rules[0].escalation_delay_in_minutes", From: 30, To: 15},
+			{Field: "escalation_rules[1]", From: nil, To: map[string]interface{}{
+				"escalation_delay_in_minutes": 5,
+				"targets":                     []string{"user PALICE1"},
+			}},
+		}))
+	})
+
+	It("should report the changed fields of the services", func() {
+		Expect(diff.Changes[3].Fields).To(Equal([]FieldChange{
+			{Field: "acknowledgement_timeout", From: 600, To: 1800},
+		}))
+	})
+
+	Context("in reverse", func() {
+		JustBeforeEach(func() {
+			diff = Compare(to, from)
+		})
+
+		It("should report the added objects as removed", func() {
+			Expect(diff.Changes[0].String()).To(Equal(`- user "carol@example.com" (PCAROL1)`))
+			Expect(diff.Changes[4].String()).To(Equal(`- service "Web" (PSVC003)`))
+		})
+
+		It("should report the extra escalation rule as removed", func() {
+			Expect(diff.Changes[2].Fields[1].Field).To(Equal("escalation_rules[1]"))
+			Expect(diff.Changes[2].Fields[1].To).To(BeNil())
+		})
+	})
+
+	Context("with a team member without an id", func() {
+		BeforeEach(func() { from.Teams[0].Members[0].User.ID = nil })
+
+		It("should leave the member out", func() {
+			Expect(diff.Changes[1].String()).To(Equal(`~ team "Platform" (PTEAM02)`))
+			Expect(diff.Changes[1].Fields).To(Equal([]FieldChange{
+				{Field: "members", From: []string{"PBOB002 manager"}, To: []string{"PALICE1 responder", "PBOB002 manager"}},
+			}))
+		})
+	})
+
+	Context("with the same snapshot", func() {
+		BeforeEach(func() { to = from })
+
+		It("should be empty", func() {
+			Expect(diff.Empty()).To(BeTrue())
+		})
+
+		It("should write no differences", func() {
+			var buf bytes.Buffer
+			Expect(diff.WriteText(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("No differences.\n"))
+
+			buf.Reset()
+			Expect(diff.WriteJSON(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchJSON(`{"changes": []}`))
+		})
+	})
+
+	Describe("writing text", func() {
+		var buf bytes.Buffer
+
+		JustBeforeEach(func() {
+			buf.Reset()
+			Expect(diff.WriteText(&buf)).To(Succeed())
+		})
+
+		It("should write the changed fields under their objects", func() {
+			Expect(buf.String()).To(Equal(`+ user "carol@example.com" (PCAROL1)
+~ schedule "Primary" (PSCHED2)
+    schedule_layers[0].rotation_turn_length_seconds: (none) -> 86400
+    schedule_layers[0].users: ["PALICE1"] -> ["PBOB002","PALICE1"]
+~ escalation policy "Default" (PEP0002)
+    escalation_rules[0].escalation_delay_in_minutes: 30 -> 15
+    escalation_rules[1]: (none) -> {"escalation_delay_in_minutes":5,"targets":["user PALICE1"]}
+~ service "API" (PSVC002)
+    acknowledgement_timeout: 600 -> 1800
++ service "Web" (PSVC003)
+
+2 added, 0 removed, 3 changed.
+`))
+		})
+	})
+
+	Describe("writing JSON", func() {
+		It("should write the changes", func() {
+			var buf bytes.Buffer
+			Expect(diff.WriteJSON(&buf)).To(Succeed())
+
+			var out struct {
+				Changes []json.RawMessage `json:"changes"`
+			}
+			Expect(json.Unmarshal(buf.Bytes(), &out)).To(Succeed())
+			Expect(out.Changes).To(HaveLen(5))
+			Expect(string(out.Changes[3])).To(MatchJSON(`{
+				"kind": "service",
+				"change": "changed",
+				"name": "API",
+				"id": "PSVC002",
+				"fields": [{"field": "acknowledgement_timeout", "from": 600, "to": 1800}]
+			}`))
+		})
+	})
+})
+
+var _ = Describe("CompareAccount", func() {
+	var (
+		server   *ghttp.Server
+		client   *pagerduty.Client
+		snapshot *Snapshot
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = pagerduty.NewClientV2(nil, "super-secret-key", "")
+		client.BaseURL, _ = url.Parse(server.URL())
+
+		for path, body := range map[string]string{
+			"/users":                 usersJSON,
+			"/teams":                 teamsJSON,
+			"/teams/PTEAM01/members": membersJSON,
+			"/schedules":             schedulesJSON,
+			"/schedules/PSCHED1":     scheduleJSON,
+			"/escalation_policies":   policiesJSON,
+			"/services":              servicesJSON,
+		} {
+			server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, body))
+		}
+
+		var err error
+		snapshot, err = Backup(context.Background(), client)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() { server.Close() })
+
+	It("should find no differences with a fresh snapshot", func() {
+		diff, err := CompareAccount(context.Background(), client, snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Empty()).To(BeTrue())
+	})
+
+	It("should report the objects deleted since the snapshot", func() {
+		server.RouteToHandler("GET", "/services", ghttp.RespondWith(http.StatusOK, `{"more": false, "services": []}`))
+
+		diff, err := CompareAccount(context.Background(), client, snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Changes).To(HaveLen(2))
+		Expect(diff.Changes[0].String()).To(Equal(`- service "API" (PSVC001)`))
+	})
+
+	It("should return the errors of the account", func() {
+		server.RouteToHandler("GET", "/teams", ghttp.RespondWith(http.StatusInternalServerError, nil))
+
+		_, err := CompareAccount(context.Background(), client, snapshot)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,417 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// Kinds of the objects of a snapshot, in the order they are restored.
+const (
+	KindUser             = "user"
+	KindTeam             = "team"
+	KindSchedule         = "schedule"
+	KindEscalationPolicy = "escalation_policy"
+	KindService          = "service"
+	KindIntegration      = "integration"
+)
+
+// Object is an object of a snapshot recreated in an account.
+type Object struct {
+	Kind string `json:"kind"`
+
+	// The name of the object, or the email address of users.
+	Name string `json:"name"`
+
+	// The id of the object in the snapshot and in the account, empty in a dry
+	// run.
+	SnapshotID string `json:"snapshot_id"`
+	ID         string `json:"id,omitempty"`
+}
+
+func (o Object) String() string {
+	if o.ID == "" {
+		return fmt.Sprintf("%s %q (%s)", kindName(o.Kind), o.Name, o.SnapshotID)
+	}
+
+	return fmt.Sprintf("%s %q (%s -> %s)", kindName(o.Kind), o.Name, o.SnapshotID, o.ID)
+}
+
+type RestoreOptions struct {
+	// Whether the missing objects are only reported rather than created.
+	DryRun bool
+}
+
+// RestoreResult is the outcome of a restore.
+type RestoreResult struct {
+	// The ids of the objects of the snapshot in the account, by their ids in
+	// the snapshot, for the objects found in or recreated in the account.
+	IDs map[string]string `json:"ids"`
+
+	// The objects missing from the account, recreated unless in a dry run, in
+	// the order they were.
+	Created []Object `json:"created"`
+}
+
+// restorer holds the state of a restore.
+type restorer struct {
+	client *pagerduty.Client
+	opts   RestoreOptions
+	result *RestoreResult
+
+	// the ids of the account, and the ids of its objects by kind and name
+	ids   map[string]bool
+	names map[string]map[string]string
+}
+
+// Restore recreates the objects of the snapshot missing from the account of
+// client, in dependency order: users, teams, schedules, escalation policies
+// and services.
+//
+// An object of the snapshot is found in the account by its id or, failing
+// that, by its name, or its email address for users. The references of the
+// recreated objects to the other objects of the snapshot are remapped to the
+// ids of the account. The members of the snapshot teams are restored whenever
+// the team or the user is recreated, and the integrations of the recreated
+// services are recreated along with them. Recreated integrations get new keys,
+// which the monitoring tools sending events to them must be given. Recreated
+// users do not get their contact methods and notification rules back, as
+// PagerDuty asks them to set them up, and a recreated account owner becomes an
+// admin, as owners cannot be created through the API.
+func Restore(ctx context.Context, client *pagerduty.Client, snapshot *Snapshot, opts *RestoreOptions) (*RestoreResult, error) {
+	r := &restorer{
+		client: client,
+		result: &RestoreResult{IDs: make(map[string]string)},
+		ids:    make(map[string]bool),
+		names:  make(map[string]map[string]string),
+	}
+	if opts != nil {
+		r.opts = *opts
+	}
+
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+
+	created := make(map[string]bool)
+	restore := func(kind, name string, snapshotID *string, create func() (*string, error)) error {
+		if snapshotID == nil {
+			return nil
+		}
+
+		if id, ok := r.find(kind, *snapshotID, name); ok {
+			r.result.IDs[*snapshotID] = id
+			return nil
+		}
+
+		o := Object{Kind: kind, Name: name, SnapshotID: *snapshotID}
+		if !r.opts.DryRun {
+			id, err := create()
+			if err != nil {
+				return fmt.Errorf("backup: restoring %s: %w", o, err)
+			}
+			if id == nil {
+				return fmt.Errorf("backup: restoring %s: created object has no id", o)
+			}
+
+			o.ID = *id
+			r.result.IDs[o.SnapshotID] = o.ID
+		}
+
+		created[o.SnapshotID] = true
+		r.result.Created = append(r.result.Created, o)
+		return nil
+	}
+
+	for _, u := range snapshot.Users {
+		u := u
+		err := restore(KindUser, strings.ToLower(id(u.Email)), u.ID, func() (*string, error) {
+			user, _, err := r.client.Users.CreateContext(ctx, &pagerduty.User{
+				Name:      u.Name,
+				Email:     u.Email,
+				TimeZone:  u.TimeZone,
+				Color:     u.Color,
+				Role:      userRole(u.Role),
+				JobTitle:  u.JobTitle,
+				APIObject: pagerduty.APIObject{Type: u.Type},
+			})
+			if err != nil || user == nil {
+				return nil, err
+			}
+			return user.ID, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, t := range snapshot.Teams {
+		t := t
+		err := restore(KindTeam, id(t.Name), t.ID, func() (*string, error) {
+			team, _, err := r.client.Teams.CreateContext(ctx, &pagerduty.Team{Name: t.Name, Description: t.Description})
+			if err != nil || team == nil {
+				return nil, err
+			}
+			return team.ID, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !r.opts.DryRun {
+		if err := r.restoreMembers(ctx, snapshot, created); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range snapshot.Schedules {
+		s := s
+		err := restore(KindSchedule, id(s.Name), s.ID, func() (*string, error) {
+			schedule, _, err := r.client.Schedules.CreateContext(ctx, r.schedule(&s), nil)
+			if err != nil || schedule == nil {
+				return nil, err
+			}
+			return schedule.ID, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range snapshot.EscalationPolicies {
+		p := p
+		err := restore(KindEscalationPolicy, id(p.Name), p.ID, func() (*string, error) {
+			policy, _, err := r.client.EscalationPolicies.CreateContext(ctx, r.escalationPolicy(&p))
+			if err != nil || policy == nil {
+				return nil, err
+			}
+			return policy.ID, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range snapshot.Services {
+		s := s
+		err := restore(KindService, id(s.Name), s.ID, func() (*string, error) {
+			service, _, err := r.client.Services.CreateContext(ctx, r.service(&s))
+			if err != nil || service == nil {
+				return nil, err
+			}
+			return service.ID, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if s.ID == nil || !created[*s.ID] {
+			continue
+		}
+		for _, in := range s.Integrations {
+			in := in
+			err := restore(KindIntegration, id(in.Name), in.ID, func() (*string, error) {
+				integration, _, err := r.client.Services.CreateIntegrationContext(ctx, *r.remap(s.ID), r.integration(&in))
+				if err != nil || integration == nil {
+					return nil, err
+				}
+				return integration.ID, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r.result, nil
+}
+
+// load lists the objects of the account.
+func (r *restorer) load(ctx context.Context) error {
+	add := func(kind string, id, name *string) {
+		if id == nil {
+			return
+		}
+
+		r.ids[*id] = true
+		if name != nil {
+			r.names[kind][*name] = *id
+		}
+	}
+
+	for _, kind := range []string{KindUser, KindTeam, KindSchedule, KindEscalationPolicy, KindService} {
+		r.names[kind] = make(map[string]string)
+	}
+
+	users, err := r.client.Users.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return fmt.Errorf("backup: listing users: %w", err)
+	}
+	for _, u := range users {
+		if u.Email != nil {
+			add(KindUser, u.ID, pagerduty.String(strings.ToLower(*u.Email)))
+		}
+	}
+
+	teams, err := r.client.Teams.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return fmt.Errorf("backup: listing teams: %w", err)
+	}
+	for _, t := range teams {
+		add(KindTeam, t.ID, t.Name)
+	}
+
+	schedules, err := r.client.Schedules.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return fmt.Errorf("backup: listing schedules: %w", err)
+	}
+	for _, s := range schedules {
+		add(KindSchedule, s.ID, s.Name)
+	}
+
+	policies, err := r.client.EscalationPolicies.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return fmt.Errorf("backup: listing escalation policies: %w", err)
+	}
+	for _, p := range policies {
+		add(KindEscalationPolicy, p.ID, p.Name)
+	}
+
+	services, err := r.client.Services.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return fmt.Errorf("backup: listing services: %w", err)
+	}
+	for _, s := range services {
+		add(KindService, s.ID, s.Name)
+	}
+
+	return nil
+}
+
+// find returns the id in the account of the object of the snapshot with the
+// given kind, id and name.
+func (r *restorer) find(kind, snapshotID, name string) (string, bool) {
+	if r.ids[snapshotID] {
+		return snapshotID, true
+	}
+
+	id, ok := r.names[kind][name]
+	return id, ok
+}
+
+// remap returns the id in the account of the object of the snapshot with the
+// given id. The ids of objects missing from the snapshot are kept as is.
+func (r *restorer) remap(snapshotID *string) *string {
+	if snapshotID == nil {
+		return nil
+	}
+
+	if id, ok := r.result.IDs[*snapshotID]; ok {
+		return pagerduty.String(id)
+	}
+	return snapshotID
+}
+
+// restoreMembers adds the members of the snapshot teams back to them, when the
+// team or the user was recreated.
+func (r *restorer) restoreMembers(ctx context.Context, snapshot *Snapshot, created map[string]bool) error {
+	for _, t := range snapshot.Teams {
+		if t.ID == nil {
+			continue
+		}
+
+		for _, m := range t.Members {
+			if m.User == nil || m.User.ID == nil || !(created[*t.ID] || created[*m.User.ID]) {
+				continue
+			}
+
+			var role string
+			if m.Role != nil {
+				role = *m.Role
+			}
+
+			teamID, userID := r.remap(t.ID), r.remap(m.User.ID)
+			if _, err := r.client.Teams.AddUserContext(ctx, *teamID, *userID, role); err != nil {
+				return fmt.Errorf("backup: restoring member %s of team %q: %w", *userID, id(t.Name), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// schedule returns the schedule creating s, with its users remapped.
+func (r *restorer) schedule(s *pagerduty.Schedule) *pagerduty.Schedule {
+	sch := &pagerduty.Schedule{Name: s.Name, TimeZone: s.TimeZone}
+	for _, l := range s.ScheduleLayers {
+		layer := l
+		layer.ID = nil
+		layer.Users = nil
+		for _, u := range l.Users {
+			layer.Users = append(layer.Users, pagerduty.User{ID: r.remap(u.ID)})
+		}
+		sch.ScheduleLayers = append(sch.ScheduleLayers, layer)
+	}
+
+	return sch
+}
+
+// escalationPolicy returns the escalation policy creating p, with its targets
+// remapped.
+func (r *restorer) escalationPolicy(p *pagerduty.EscalationPolicy) *pagerduty.EscalationPolicy {
+	policy := &pagerduty.EscalationPolicy{Name: p.Name, NumLoops: p.NumLoops}
+	for _, rule := range p.EscalationRules {
+		rl := pagerduty.EscalationRule{EscalationDelayInMinutes: rule.EscalationDelayInMinutes}
+		for _, t := range rule.Targets {
+			rl.Targets = append(rl.Targets, pagerduty.Target{ID: r.remap(t.ID), Type: t.Type})
+		}
+		policy.EscalationRules = append(policy.EscalationRules, rl)
+	}
+
+	return policy
+}
+
+// service returns the service creating s, with its escalation policy
+// remapped.
+func (r *restorer) service(s *pagerduty.Service) *pagerduty.Service {
+	svc := &pagerduty.Service{
+		Name:                   s.Name,
+		Description:            s.Description,
+		AutoResolveTimeout:     s.AutoResolveTimeout,
+		AcknowledgementTimeout: s.AcknowledgementTimeout,
+		EmailIncidentCreation:  s.EmailIncidentCreation,
+		EmailFilterMode:        s.EmailFilterMode,
+		Type:                   s.Type,
+		EmailFilters:           s.EmailFilters,
+		SeverityFilter:         s.SeverityFilter,
+	}
+	if s.EscalationPolicy != nil {
+		svc.EscalationPolicy = &pagerduty.EscalationPolicy{ID: r.remap(s.EscalationPolicy.ID)}
+	}
+
+	return svc
+}
+
+// userRole returns the role of a recreated user with the given role.
+func userRole(role *string) *string {
+	if role != nil && *role == "owner" {
+		return pagerduty.String("admin")
+	}
+	return role
+}
+
+// integration returns the integration recreating in.
+func (r *restorer) integration(in *pagerduty.Integration) *pagerduty.Integration {
+	integration := &pagerduty.Integration{Name: in.Name, IntegrationEmail: in.IntegrationEmail}
+	if in.Type != nil {
+		integration.Type = pagerduty.String(strings.TrimSuffix(*in.Type, "_reference"))
+	}
+	if in.Vendor != nil && in.Vendor.ID != nil {
+		integration.Vendor = pagerduty.NewVendorReference(*in.Vendor.ID)
+	}
+
+	return integration
+}
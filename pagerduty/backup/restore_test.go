@@ -0,0 +1,238 @@
+package backup_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/backup"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const snapshotJSON = `{
+  "version": 1,
+  "users": [
+    {"id": "PALICE1", "name": "Alice", "email": "alice@example.com"},
+    {"id": "PBOB001", "name": "Bob", "email": "bob@example.com", "role": "user", "type": "user"}
+  ],
+  "teams": [
+    {"id": "PTEAM01", "name": "Platform", "members": [
+      {"user": {"id": "PALICE1"}, "role": "responder"},
+      {"user": {"id": "PBOB001"}, "role": "manager"}
+    ]}
+  ],
+  "schedules": [
+    {"id": "PSCHED1", "name": "Primary", "time_zone": "UTC", "schedule_layers": [
+      {"id": "PLAYER1", "name": "Weekly", "start": "2015-11-07T01:00:00Z", "users": [{"user": {"id": "PALICE1"}}]}
+    ]}
+  ],
+  "escalation_policies": [
+    {"id": "PEP0001", "name": "Default", "escalation_rules": [
+      {"id": "PRULE01", "escalation_delay_in_minutes": 30, "targets": [
+        {"id": "PSCHED1", "type": "schedule_reference"},
+        {"id": "PBOB001", "type": "user_reference"}
+      ]}
+    ]}
+  ],
+  "services": [
+    {"id": "PSVC001", "name": "API", "acknowledgement_timeout": 600, "escalation_policy": {"id": "PEP0001"}, "integrations": [
+      {"id": "PINT001", "type": "generic_events_api_inbound_integration", "name": "Monitoring", "vendor": {"id": "PVEND01"}}
+    ]}
+  ]
+}`
+
+var _ = Describe("Restore", func() {
+	var (
+		server   *ghttp.Server
+		client   *pagerduty.Client
+		snapshot *Snapshot
+		opts     *RestoreOptions
+
+		result *RestoreResult
+		err    error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = pagerduty.NewClientV2(nil, "super-secret-key", "")
+		client.BaseURL, _ = url.Parse(server.URL())
+		opts = nil
+
+		// Alice is still there and the schedule was recreated by hand under
+		// another id, while the rest was deleted.
+		for path, body := range map[string]string{
+			"/users":               `{"more": false, "users": [{"id": "PALICE1", "email": "Alice@example.com"}]}`,
+			"/teams":               `{"more": false, "teams": []}`,
+			"/schedules":           `{"more": false, "schedules": [{"id": "PSCHED2", "name": "Primary"}]}`,
+			"/escalation_policies": `{"more": false, "escalation_policies": []}`,
+			"/services":            `{"more": false, "services": []}`,
+		} {
+			server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, body))
+		}
+
+		server.RouteToHandler("POST", "/users", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"user": {"name": "Bob", "email": "bob@example.com", "role": "user", "type": "user"}}`),
+			ghttp.RespondWith(http.StatusCreated, `{"user": {"id": "PBOB002"}}`),
+		))
+		server.RouteToHandler("POST", "/teams", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"team": {"name": "Platform"}}`),
+			ghttp.RespondWith(http.StatusCreated, `{"team": {"id": "PTEAM02"}}`),
+		))
+		server.RouteToHandler("PUT", "/teams/PTEAM02/users/PALICE1", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"role": "responder"}`),
+			ghttp.RespondWith(http.StatusNoContent, nil),
+		))
+		server.RouteToHandler("PUT", "/teams/PTEAM02/users/PBOB002", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"role": "manager"}`),
+			ghttp.RespondWith(http.StatusNoContent, nil),
+		))
+		server.RouteToHandler("POST", "/escalation_policies", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"escalation_policy": {"name": "Default", "escalation_rules": [{
+				"escalation_delay_in_minutes": 30,
+				"targets": [{"id": "PSCHED2", "type": "schedule_reference"}, {"id": "PBOB002", "type": "user_reference"}]
+			}]}}`),
+			ghttp.RespondWith(http.StatusCreated, `{"escalation_policy": {"id": "PEP0002"}}`),
+		))
+		server.RouteToHandler("POST", "/services", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"service": {
+				"name": "API",
+				"acknowledgement_timeout": 600,
+				"escalation_policy": {"id": "PEP0002", "type": "escalation_policy_reference"}
+			}}`),
+			ghttp.RespondWith(http.StatusCreated, `{"service": {"id": "PSVC002"}}`),
+		))
+		server.RouteToHandler("POST", "/services/PSVC002/integrations", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"integration": {
+				"type": "generic_events_api_inbound_integration",
+				"name": "Monitoring",
+				"vendor": {"id": "PVEND01", "type": "vendor_reference"}
+			}}`),
+			ghttp.RespondWith(http.StatusCreated, `{"integration": {"id": "PINT002", "integration_key": "f4f3c1f2b6b84a30a2f5b7c0d6e1a9b8"}}`),
+		))
+
+		snapshot, err = Read(strings.NewReader(snapshotJSON))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() { server.Close() })
+
+	JustBeforeEach(func() {
+		result, err = Restore(context.Background(), client, snapshot, opts)
+	})
+
+	It("should not return an error", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should map the ids of the snapshot to the ones of the account", func() {
+		Expect(result.IDs).To(Equal(map[string]string{
+			"PALICE1": "PALICE1",
+			"PBOB001": "PBOB002",
+			"PTEAM01": "PTEAM02",
+			"PSCHED1": "PSCHED2",
+			"PEP0001": "PEP0002",
+			"PSVC001": "PSVC002",
+			"PINT001": "PINT002",
+		}))
+	})
+
+	It("should recreate the missing objects in dependency order", func() {
+		Expect(result.Created).To(Equal([]Object{
+			{Kind: KindUser, Name: "bob@example.com", SnapshotID: "PBOB001", ID: "PBOB002"},
+			{Kind: KindTeam, Name: "Platform", SnapshotID: "PTEAM01", ID: "PTEAM02"},
+			{Kind: KindEscalationPolicy, Name: "Default", SnapshotID: "PEP0001", ID: "PEP0002"},
+			{Kind: KindService, Name: "API", SnapshotID: "PSVC001", ID: "PSVC002"},
+			{Kind: KindIntegration, Name: "Monitoring", SnapshotID: "PINT001", ID: "PINT002"},
+		}))
+	})
+
+	It("should restore the members of the recreated team", func() {
+		var paths []string
+		for _, r := range server.ReceivedRequests() {
+			if r.Method == "PUT" {
+				paths = append(paths, r.URL.Path)
+			}
+		}
+
+		Expect(paths).To(Equal([]string{"/teams/PTEAM02/users/PALICE1", "/teams/PTEAM02/users/PBOB002"}))
+	})
+
+	It("should describe the recreated objects", func() {
+		Expect(result.Created[2].String()).To(Equal(`escalation policy "Default" (PEP0001 -> PEP0002)`))
+	})
+
+	Context("in a dry run", func() {
+		BeforeEach(func() { opts = &RestoreOptions{DryRun: true} })
+
+		It("should report the missing objects", func() {
+			Expect(result.Created).To(HaveLen(5))
+			Expect(result.Created[0]).To(Equal(Object{Kind: KindUser, Name: "bob@example.com", SnapshotID: "PBOB001"}))
+			Expect(result.Created[0].String()).To(Equal(`user "bob@example.com" (PBOB001)`))
+		})
+
+		It("should only map the ids of the objects found", func() {
+			Expect(result.IDs).To(Equal(map[string]string{"PALICE1": "PALICE1", "PSCHED1": "PSCHED2"}))
+		})
+
+		It("should not change the account", func() {
+			for _, r := range server.ReceivedRequests() {
+				Expect(r.Method).To(Equal("GET"))
+			}
+		})
+	})
+
+	Context("with the account owner", func() {
+		BeforeEach(func() {
+			snapshot.Users[1].Role = pagerduty.String("owner")
+			server.RouteToHandler("POST", "/users", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"user": {"name": "Bob", "email": "bob@example.com", "role": "admin", "type": "user"}}`),
+				ghttp.RespondWith(http.StatusCreated, `{"user": {"id": "PBOB002"}}`),
+			))
+		})
+
+		It("should recreate them as an admin", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IDs).To(HaveKeyWithValue("PBOB001", "PBOB002"))
+		})
+	})
+
+	Context("when recreating an object fails", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("POST", "/escalation_policies", ghttp.RespondWith(http.StatusBadRequest, `{"error": {"message": "Invalid Input Provided"}}`))
+		})
+
+		It("should return the error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix(`backup: restoring escalation policy "Default" (PEP0001): `))
+		})
+	})
+
+	Context("with a team without an id", func() {
+		BeforeEach(func() {
+			snapshot.Teams[0].ID = nil
+		})
+
+		It("should skip the team and its members", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Created).To(HaveLen(4))
+			for _, r := range server.ReceivedRequests() {
+				Expect(r.Method).NotTo(Equal("PUT"))
+			}
+		})
+	})
+
+	Context("when the created object is missing from the response", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("POST", "/users", ghttp.RespondWith(http.StatusCreated, `{}`))
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(`backup: restoring user "bob@example.com" (PBOB001): created object has no id`))
+		})
+	})
+})
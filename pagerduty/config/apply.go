@@ -0,0 +1,148 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// Apply applies the changes of the plan in order, writing each of them to out,
+// when it is not nil, once applied. The references to the resources created
+// along the way are resolved as they get created. It stops at the first
+// failing change.
+func (p *Planner) Apply(ctx context.Context, plan *Plan, out io.Writer) error {
+	for _, c := range plan.Changes {
+		if err := p.apply(ctx, plan, &c); err != nil {
+			return fmt.Errorf("config: %s: %w", c.describe(), err)
+		}
+
+		if out != nil {
+			fmt.Fprintln(out, c)
+		}
+	}
+
+	return nil
+}
+
+func (p *Planner) apply(ctx context.Context, plan *Plan, c *Change) error {
+	if c.Action == ActionDelete {
+		return p.delete(ctx, c.Kind, c.ID)
+	}
+
+	r, err := p.resolve(ctx, plan, c.resource, true)
+	if err != nil {
+		return err
+	}
+
+	var id *string
+	switch r := r.(type) {
+	case *pagerduty.Team:
+		var team *pagerduty.Team
+		if c.Action == ActionUpdate {
+			r.ID = pagerduty.String(c.ID)
+			team, _, err = p.client.Teams.EditContext(ctx, r)
+		} else {
+			team, _, err = p.client.Teams.CreateContext(ctx, r)
+		}
+		if team != nil {
+			id = team.ID
+		}
+
+	case *pagerduty.Schedule:
+		var schedule *pagerduty.Schedule
+		if c.Action == ActionUpdate {
+			r.ID = pagerduty.String(c.ID)
+
+			// keep the layers of the account, rather than ending them and
+			// starting new ones
+			if current, ok := c.current.(*pagerduty.Schedule); ok {
+				for i := range r.ScheduleLayers {
+					if i < len(current.ScheduleLayers) {
+						r.ScheduleLayers[i].ID = current.ScheduleLayers[i].ID
+					}
+				}
+			}
+
+			schedule, _, err = p.client.Schedules.UpdateContext(ctx, r, nil)
+		} else {
+			schedule, _, err = p.client.Schedules.CreateContext(ctx, r, nil)
+		}
+		if schedule != nil {
+			id = schedule.ID
+		}
+
+	case *pagerduty.EscalationPolicy:
+		var policy *pagerduty.EscalationPolicy
+		if c.Action == ActionUpdate {
+			r.ID = pagerduty.String(c.ID)
+			policy, _, err = p.client.EscalationPolicies.UpdateContext(ctx, r)
+		} else {
+			policy, _, err = p.client.EscalationPolicies.CreateContext(ctx, r)
+		}
+		if policy != nil {
+			id = policy.ID
+		}
+
+	case *pagerduty.Service:
+		var service *pagerduty.Service
+		if c.Action == ActionUpdate {
+			r.ID = pagerduty.String(c.ID)
+			service, _, err = p.client.Services.UpdateContext(ctx, r)
+		} else {
+			service, _, err = p.client.Services.CreateContext(ctx, r)
+		}
+		if service != nil {
+			id = service.ID
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.Action == ActionCreate {
+		if id == nil {
+			return fmt.Errorf("created %s has no id", c.Kind)
+		}
+		plan.ids[c.Kind][c.Name] = *id
+	}
+
+	return nil
+}
+
+func (p *Planner) delete(ctx context.Context, kind Kind, id string) error {
+	var err error
+	switch kind {
+	case KindTeam:
+		_, err = p.client.Teams.DeleteContext(ctx, id)
+	case KindSchedule:
+		_, err = p.client.Schedules.DeleteContext(ctx, id)
+	case KindEscalationPolicy:
+		_, err = p.client.EscalationPolicies.DeleteContext(ctx, id)
+	case KindService:
+		_, err = p.client.Services.DeleteContext(ctx, id)
+	default:
+		err = fmt.Errorf("unknown kind %q", kind)
+	}
+
+	return err
+}
+
+// Sync plans the changes making the account match cfg, writes the plan to out
+// and, unless dryRun is set, applies it.
+func (p *Planner) Sync(ctx context.Context, cfg *Config, dryRun bool, out io.Writer) (*Plan, error) {
+	plan, err := p.Plan(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := plan.WriteTo(out); err != nil {
+		return plan, err
+	}
+	if dryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	return plan, p.Apply(ctx, plan, out)
+}
@@ -0,0 +1,123 @@
+package config_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"bytes"
+	"context"
+	"net/http"
+)
+
+var _ = Describe("Applying", func() {
+	var (
+		server  *ghttp.Server
+		planner *Planner
+		plan    *Plan
+		out     bytes.Buffer
+		err     error
+
+		// the number of requests made while planning
+		planned int
+	)
+
+	BeforeEach(func() {
+		var client *pagerduty.Client
+		server, client = newAccount()
+		planner = NewPlanner(client)
+		planner.Prune = true
+		out.Reset()
+
+		server.RouteToHandler("PUT", "/teams/PTEAM01", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"team": {"id": "PTEAM01", "name": "Platform", "description": "Platform team"}}`),
+			ghttp.RespondWith(http.StatusOK, `{"team": {"id": "PTEAM01"}}`),
+		))
+		server.RouteToHandler("PUT", "/schedules/PSCHED1", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"schedule": {
+				"id": "PSCHED1",
+				"name": "Primary",
+				"time_zone": "UTC",
+				"schedule_layers": [{
+					"id": "PLAYER1",
+					"name": "Weekly",
+					"start": "2015-11-06T20:00:00-05:00",
+					"rotation_virtual_start": "2015-11-06T20:00:00-05:00",
+					"rotation_turn_length_seconds": 604800,
+					"users": [{"user": {"id": "PALICE1", "type": "user_reference"}}]
+				}]
+			}}`),
+			ghttp.RespondWith(http.StatusOK, primaryJSON),
+		))
+		server.RouteToHandler("POST", "/schedules", ghttp.RespondWith(http.StatusCreated, `{"schedule": {"id": "PSCHED2", "name": "Secondary"}}`))
+		server.RouteToHandler("PUT", "/escalation_policies/PEP0001", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"escalation_policy": {
+				"id": "PEP0001",
+				"name": "Default",
+				"escalation_rules": [{
+					"escalation_delay_in_minutes": 30,
+					"targets": [{"id": "PSCHED2", "type": "schedule_reference"}]
+				}]
+			}}`),
+			ghttp.RespondWith(http.StatusOK, `{"escalation_policy": {"id": "PEP0001"}}`),
+		))
+		server.RouteToHandler("DELETE", "/services/PSVC009", ghttp.RespondWith(http.StatusNoContent, nil))
+		server.RouteToHandler("DELETE", "/schedules/PSCHED9", ghttp.RespondWith(http.StatusNoContent, nil))
+
+		cfg, err := Parse([]byte(configYAML))
+		Expect(err).NotTo(HaveOccurred())
+
+		plan, err = planner.Plan(context.Background(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		planned = len(server.ReceivedRequests())
+	})
+
+	AfterEach(func() { server.Close() })
+
+	JustBeforeEach(func() {
+		err = planner.Apply(context.Background(), plan, &out)
+	})
+
+	It("should not return an error", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should apply the changes in order", func() {
+		var paths []string
+		for _, r := range server.ReceivedRequests()[planned:] {
+			paths = append(paths, r.Method+" "+r.URL.Path)
+		}
+
+		Expect(paths).To(Equal([]string{
+			"PUT /teams/PTEAM01",
+			"PUT /schedules/PSCHED1",
+			"POST /schedules",
+			"PUT /escalation_policies/PEP0001",
+			"DELETE /services/PSVC009",
+			"DELETE /schedules/PSCHED9",
+		}))
+	})
+
+	It("should write the applied changes", func() {
+		Expect(out.String()).To(HavePrefix(`~ update team "Platform" (PTEAM01)` + "\n"))
+		Expect(out.String()).To(HaveSuffix(`- delete schedule "Legacy" (PSCHED9)` + "\n"))
+	})
+
+	Context("when a change fails", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("POST", "/schedules", ghttp.RespondWith(http.StatusBadRequest, `{"error": {"message": "Invalid Input Provided"}}`))
+		})
+
+		It("should return the error of the change", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix(`config: create schedule "Secondary": `))
+		})
+
+		It("should stop at the failing change", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(planned + 3))
+		})
+	})
+})
@@ -0,0 +1,236 @@
+// Package config manages the teams, schedules, escalation policies and
+// services of a PagerDuty account declaratively.
+//
+// A configuration is written in YAML or JSON files, using the same fields as
+// the API, and loaded into the types of the pagerduty package:
+//
+//	schedules:
+//	  - name: Primary
+//	    time_zone: America/New_York
+//	    schedule_layers:
+//	      - name: Weekly
+//	        start: 2015-11-06T20:00:00-05:00
+//	        rotation_virtual_start: 2015-11-06T20:00:00-05:00
+//	        rotation_turn_length_seconds: 604800
+//	        users:
+//	          - email: alice@example.com
+//	          - id: PXPGF42
+//	escalation_policies:
+//	  - name: Default
+//	    escalation_rules:
+//	      - escalation_delay_in_minutes: 30
+//	        targets:
+//	          - type: schedule
+//	            name: Primary
+//	services:
+//	  - name: API
+//	    escalation_policy:
+//	      name: Default
+//
+// Resources are identified by their names. They reference each other by name
+// or by id, and users by email address or by id, so that a configuration can
+// create a schedule and the escalation policy using it at once.
+//
+// A Planner compares a configuration with the account and plans the creates,
+// updates and deletes making the account match it, which can be reviewed
+// before being applied in dependency order: teams, then schedules, then
+// escalation policies, then services.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the desired set of resources of an account.
+type Config struct {
+	Teams              []pagerduty.Team             `json:"teams,omitempty"`
+	Schedules          []pagerduty.Schedule         `json:"schedules,omitempty"`
+	EscalationPolicies []pagerduty.EscalationPolicy `json:"escalation_policies,omitempty"`
+	Services           []pagerduty.Service          `json:"services,omitempty"`
+}
+
+// Parse parses a configuration written in YAML or JSON. Unknown fields are
+// rejected, to catch typos.
+func Parse(data []byte) (*Config, error) {
+	cfg, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func parse(data []byte) (*Config, error) {
+	// YAML is decoded generically, then converted to JSON so that the JSON
+	// representations of the types of the pagerduty package apply. JSON being
+	// YAML, both formats go through the same path.
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	cfg := new(Config)
+	if v == nil {
+		return cfg, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Load reads and merges the configurations of the given files, and of the
+// .yaml, .yml and .json files of the given directories, in lexical order.
+func Load(paths ...string) (*Config, error) {
+	cfg := new(Config)
+	for _, path := range paths {
+		files := []string{path}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil, fmt.Errorf("config: %w", err)
+			}
+
+			files = nil
+			for _, e := range entries {
+				switch strings.ToLower(filepath.Ext(e.Name())) {
+				case ".yaml", ".yml", ".json":
+					if !e.IsDir() {
+						files = append(files, filepath.Join(path, e.Name()))
+					}
+				}
+			}
+			sort.Strings(files)
+		}
+
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("config: %w", err)
+			}
+
+			c, err := parse(data)
+			if err != nil {
+				return nil, fmt.Errorf("config: %s: %w", file, err)
+			}
+			cfg.merge(c)
+		}
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) merge(o *Config) {
+	c.Teams = append(c.Teams, o.Teams...)
+	c.Schedules = append(c.Schedules, o.Schedules...)
+	c.EscalationPolicies = append(c.EscalationPolicies, o.EscalationPolicies...)
+	c.Services = append(c.Services, o.Services...)
+}
+
+// Validate checks that every resource has a name unique among the resources
+// of its kind, and that the references of the resources name or identify what
+// they reference.
+func (c *Config) Validate() error {
+	names := func(kind Kind, n int, name func(i int) *string) error {
+		seen := make(map[string]bool)
+		for i := 0; i < n; i++ {
+			v := name(i)
+			if v == nil || *v == "" {
+				return fmt.Errorf("config: %s #%d has no name", kind, i+1)
+			}
+			if seen[*v] {
+				return fmt.Errorf("config: %s %q is defined twice", kind, *v)
+			}
+			seen[*v] = true
+		}
+		return nil
+	}
+
+	if err := names(KindTeam, len(c.Teams), func(i int) *string { return c.Teams[i].Name }); err != nil {
+		return err
+	}
+	if err := names(KindSchedule, len(c.Schedules), func(i int) *string { return c.Schedules[i].Name }); err != nil {
+		return err
+	}
+	if err := names(KindEscalationPolicy, len(c.EscalationPolicies), func(i int) *string { return c.EscalationPolicies[i].Name }); err != nil {
+		return err
+	}
+	if err := names(KindService, len(c.Services), func(i int) *string { return c.Services[i].Name }); err != nil {
+		return err
+	}
+
+	for _, s := range c.Schedules {
+		for i, l := range s.ScheduleLayers {
+			for j, u := range l.Users {
+				if isEmpty(u.ID) && isEmpty(u.Email) {
+					return fmt.Errorf("config: schedule %q: schedule_layers[%d].users[%d] needs an id or an email", *s.Name, i, j)
+				}
+			}
+		}
+	}
+
+	for _, p := range c.EscalationPolicies {
+		for i, r := range p.EscalationRules {
+			for j, t := range r.Targets {
+				field := fmt.Sprintf("escalation_rules[%d].targets[%d]", i, j)
+
+				switch targetType(t) {
+				case pagerduty.TargetTypeUser:
+					if isEmpty(t.ID) && isEmpty(t.Email) {
+						return fmt.Errorf("config: escalation policy %q: %s needs an id or an email", *p.Name, field)
+					}
+				case pagerduty.TargetTypeSchedule:
+					if isEmpty(t.ID) && isEmpty(t.Name) {
+						return fmt.Errorf("config: escalation policy %q: %s needs an id or a name", *p.Name, field)
+					}
+				default:
+					return fmt.Errorf("config: escalation policy %q: %s must be a user or a schedule", *p.Name, field)
+				}
+			}
+		}
+	}
+
+	for _, s := range c.Services {
+		if ep := s.EscalationPolicy; ep == nil || (isEmpty(ep.ID) && isEmpty(ep.Name)) {
+			return fmt.Errorf("config: service %q needs an escalation policy id or name", *s.Name)
+		}
+	}
+
+	return nil
+}
+
+// targetType returns the type of t, without the suffix of REST API v2
+// references.
+func targetType(t pagerduty.Target) string {
+	if t.Type == nil {
+		return ""
+	}
+	return strings.TrimSuffix(*t.Type, "_reference")
+}
+
+func isEmpty(s *string) bool {
+	return s == nil || *s == ""
+}
@@ -0,0 +1,175 @@
+package config_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const configYAML = `
+teams:
+  - name: Platform
+    description: Platform team
+schedules:
+  - name: Primary
+    time_zone: UTC
+    schedule_layers:
+      - name: Weekly
+        start: 2015-11-06T20:00:00-05:00
+        rotation_virtual_start: 2015-11-06T20:00:00-05:00
+        rotation_turn_length_seconds: 604800
+        users:
+          - email: Alice@example.com
+  - name: Secondary
+    time_zone: UTC
+    schedule_layers:
+      - name: Weekly
+        start: 2015-11-06T20:00:00-05:00
+        rotation_virtual_start: 2015-11-06T20:00:00-05:00
+        rotation_turn_length_seconds: 604800
+        users:
+          - id: PBOB001
+escalation_policies:
+  - name: Default
+    escalation_rules:
+      - escalation_delay_in_minutes: 30
+        targets:
+          - type: schedule
+            name: Secondary
+services:
+  - name: API
+    escalation_policy:
+      name: Default
+`
+
+var _ = Describe("Config", func() {
+	Describe("parsing YAML", func() {
+		var (
+			cfg *Config
+			err error
+		)
+
+		BeforeEach(func() {
+			cfg, err = Parse([]byte(configYAML))
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return the resources", func() {
+			Expect(cfg.Teams).To(HaveLen(1))
+			Expect(cfg.Schedules).To(HaveLen(2))
+			Expect(cfg.EscalationPolicies).To(HaveLen(1))
+			Expect(cfg.Services).To(HaveLen(1))
+
+			Expect(*cfg.Teams[0].Description).To(Equal("Platform team"))
+			Expect(*cfg.EscalationPolicies[0].EscalationRules[0].Targets[0].Name).To(Equal("Secondary"))
+			Expect(*cfg.Services[0].EscalationPolicy.Name).To(Equal("Default"))
+		})
+
+		It("should decode the fields through their JSON representations", func() {
+			layer := cfg.Schedules[0].ScheduleLayers[0]
+			Expect(cfg.Schedules[0].TimeZone.String()).To(Equal("UTC"))
+			Expect(layer.Start.Equal(time.Date(2015, time.November, 7, 1, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(*layer.RotationTurnLengthSeconds).To(Equal(604800))
+			Expect(*layer.Users[0].Email).To(Equal("Alice@example.com"))
+		})
+
+		It("should return a valid configuration", func() {
+			Expect(cfg.Validate()).To(Succeed())
+		})
+	})
+
+	Describe("parsing JSON", func() {
+		It("should return the resources", func() {
+			cfg, err := Parse([]byte(`{"teams": [{"name": "Platform"}]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Teams).To(Equal([]pagerduty.Team{{Name: pagerduty.String("Platform")}}))
+		})
+	})
+
+	It("should reject unknown fields", func() {
+		_, err := Parse([]byte("teams:\n  - nmae: Platform\n"))
+		Expect(err).To(MatchError(`config: json: unknown field "nmae"`))
+	})
+
+	It("should return an empty configuration for an empty document", func() {
+		Expect(Parse(nil)).To(Equal(&Config{}))
+	})
+
+	Describe("loading", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "config")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.WriteFile(filepath.Join(dir, "b.yml"), []byte("teams:\n  - name: Payments\n"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"teams": [{"name": "Platform"}]}`), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Teams"), 0600)).To(Succeed())
+		})
+
+		AfterEach(func() { os.RemoveAll(dir) })
+
+		It("should merge the files of directories in lexical order", func() {
+			cfg, err := Load(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Teams).To(Equal([]pagerduty.Team{
+				{Name: pagerduty.String("Platform")},
+				{Name: pagerduty.String("Payments")},
+			}))
+		})
+
+		It("should report the invalid file", func() {
+			path := filepath.Join(dir, "c.yaml")
+			Expect(os.WriteFile(path, []byte("teams: {"), 0600)).To(Succeed())
+
+			_, err := Load(dir)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("config: " + path + ": "))
+		})
+	})
+
+	Describe("validating", func() {
+		var cfg *Config
+
+		BeforeEach(func() {
+			var err error
+			cfg, err = Parse([]byte(configYAML))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject resources without a name", func() {
+			cfg.Teams = append(cfg.Teams, pagerduty.Team{})
+			Expect(cfg.Validate()).To(MatchError("config: team #2 has no name"))
+		})
+
+		It("should reject resources defined twice", func() {
+			cfg.EscalationPolicies = append(cfg.EscalationPolicies, cfg.EscalationPolicies[0])
+			Expect(cfg.Validate()).To(MatchError(`config: escalation policy "Default" is defined twice`))
+		})
+
+		It("should reject users referenced by neither id nor email", func() {
+			cfg.Schedules[0].ScheduleLayers[0].Users[0].Email = nil
+			Expect(cfg.Validate()).To(MatchError(`config: schedule "Primary": schedule_layers[0].users[0] needs an id or an email`))
+		})
+
+		It("should reject targets of unknown types", func() {
+			cfg.EscalationPolicies[0].EscalationRules[0].Targets[0].Type = pagerduty.String("team")
+			Expect(cfg.Validate()).To(MatchError(`config: escalation policy "Default": escalation_rules[0].targets[0] must be a user or a schedule`))
+		})
+
+		It("should reject services without an escalation policy", func() {
+			cfg.Services[0].EscalationPolicy = nil
+			Expect(cfg.Validate()).To(MatchError(`config: service "API" needs an escalation policy id or name`))
+		})
+	})
+})
@@ -0,0 +1,565 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// Kind is the kind of a resource of a configuration.
+type Kind string
+
+// Kinds of resources, in dependency order.
+const (
+	KindTeam             Kind = "team"
+	KindSchedule         Kind = "schedule"
+	KindEscalationPolicy Kind = "escalation_policy"
+	KindService          Kind = "service"
+)
+
+var kinds = []Kind{KindTeam, KindSchedule, KindEscalationPolicy, KindService}
+
+func (k Kind) String() string {
+	return strings.ReplaceAll(string(k), "_", " ")
+}
+
+// Action is what a change does to a resource.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+var signs = map[Action]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
+
+// knownAfterApply stands for the id of a resource the plan creates, in the
+// differences of the resources referencing it.
+const knownAfterApply = "(known after apply)"
+
+// Diff is the change of a field of a resource, with its JSON path, e.g.
+// 'escalation_rules[0].targets', and its JSON encoded values.
+type Diff struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Change is a create, update or delete of a resource of the account.
+type Change struct {
+	Action Action `json:"action"`
+	Kind   Kind   `json:"kind"`
+	Name   string `json:"name"`
+
+	// The id of the resource in the account, empty for creates.
+	ID string `json:"id,omitempty"`
+
+	// The changed fields of the resource, for updates.
+	Diffs []Diff `json:"diffs,omitempty"`
+
+	// the resource of the configuration, for creates and updates, and the
+	// one of the account, for updates
+	resource interface{}
+	current  interface{}
+}
+
+// String describes the change on a line, prefixed with '+' for creates, '~'
+// for updates and '-' for deletes.
+func (c Change) String() string {
+	return signs[c.Action] + " " + c.describe()
+}
+
+func (c Change) describe() string {
+	if c.ID == "" {
+		return fmt.Sprintf("%s %s %q", c.Action, c.Kind, c.Name)
+	}
+
+	return fmt.Sprintf("%s %s %q (%s)", c.Action, c.Kind, c.Name, c.ID)
+}
+
+// Plan is the list of changes making an account match a configuration, in the
+// order they are applied.
+type Plan struct {
+	Changes []Change `json:"changes"`
+
+	// the ids of the resources of the account by kind and name, completed
+	// with the ids of the created ones while applying, and the names of the
+	// resources of the configuration
+	ids     map[Kind]map[string]string
+	defined map[Kind]map[string]bool
+
+	// the names of the account shared by several resources of a kind
+	ambiguous map[Kind]map[string]bool
+
+	// the ids of the users by lower case email address, listed on first use
+	users map[string]string
+}
+
+// Empty reports whether the account already matches the configuration.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// WriteTo writes the changes of the plan to w along with the changed fields of
+// the updated resources, followed by a summary.
+func (p *Plan) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	if p.Empty() {
+		fmt.Fprintln(&buf, "No changes. The account matches the configuration.")
+	}
+
+	counts := make(map[Action]int)
+	for _, c := range p.Changes {
+		counts[c.Action]++
+
+		fmt.Fprintln(&buf, c)
+		for _, d := range c.Diffs {
+			fmt.Fprintf(&buf, "    %s: %s -> %s\n", d.Field, d.From, d.To)
+		}
+	}
+
+	if !p.Empty() {
+		fmt.Fprintf(&buf, "\nPlan: %d to create, %d to update, %d to delete.\n",
+			counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete])
+	}
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// Planner plans and applies the changes making an account match a
+// configuration.
+type Planner struct {
+	client *pagerduty.Client
+
+	// Whether the resources of the account missing from the configuration are
+	// deleted. Only the kinds of resources the configuration defines are
+	// pruned. Defaults to false, leaving them untouched.
+	Prune bool
+}
+
+// NewPlanner returns a Planner managing the account of client.
+func NewPlanner(client *pagerduty.Client) *Planner {
+	return &Planner{client: client}
+}
+
+// account lists the resources of the account by kind, and returns them along
+// with their ids, by name.
+func (p *Planner) account(ctx context.Context, plan *Plan) (map[Kind]map[string]interface{}, error) {
+	resources := make(map[Kind]map[string]interface{})
+	add := func(kind Kind, id, name *string, r interface{}) {
+		if id == nil || name == nil {
+			return
+		}
+
+		if _, ok := plan.ids[kind][*name]; ok {
+			plan.ambiguous[kind][*name] = true
+		}
+		plan.ids[kind][*name] = *id
+		resources[kind][*name] = r
+	}
+
+	for _, kind := range kinds {
+		resources[kind] = make(map[string]interface{})
+		plan.ids[kind] = make(map[string]string)
+		plan.ambiguous[kind] = make(map[string]bool)
+	}
+
+	teams, err := p.client.Teams.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("config: listing teams: %w", err)
+	}
+	for i := range teams {
+		add(KindTeam, teams[i].ID, teams[i].Name, &teams[i])
+	}
+
+	schedules, err := p.client.Schedules.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("config: listing schedules: %w", err)
+	}
+	for i := range schedules {
+		add(KindSchedule, schedules[i].ID, schedules[i].Name, &schedules[i])
+	}
+
+	policies, err := p.client.EscalationPolicies.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("config: listing escalation policies: %w", err)
+	}
+	for i := range policies {
+		add(KindEscalationPolicy, policies[i].ID, policies[i].Name, &policies[i])
+	}
+
+	services, err := p.client.Services.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("config: listing services: %w", err)
+	}
+	for i := range services {
+		add(KindService, services[i].ID, services[i].Name, &services[i])
+	}
+
+	return resources, nil
+}
+
+// Plan fetches the resources of the account, and returns the changes making
+// it match cfg.
+func (p *Planner) Plan(ctx context.Context, cfg *Config) (*Plan, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		ids:       make(map[Kind]map[string]string),
+		defined:   make(map[Kind]map[string]bool),
+		ambiguous: make(map[Kind]map[string]bool),
+	}
+
+	account, err := p.account(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := make(map[Kind][]interface{})
+	for i := range cfg.Teams {
+		desired[KindTeam] = append(desired[KindTeam], &cfg.Teams[i])
+	}
+	for i := range cfg.Schedules {
+		desired[KindSchedule] = append(desired[KindSchedule], &cfg.Schedules[i])
+	}
+	for i := range cfg.EscalationPolicies {
+		desired[KindEscalationPolicy] = append(desired[KindEscalationPolicy], &cfg.EscalationPolicies[i])
+	}
+	for i := range cfg.Services {
+		desired[KindService] = append(desired[KindService], &cfg.Services[i])
+	}
+
+	for _, kind := range kinds {
+		plan.defined[kind] = make(map[string]bool)
+		for _, r := range desired[kind] {
+			plan.defined[kind][nameOf(r)] = true
+		}
+	}
+
+	for _, kind := range kinds {
+		for _, r := range desired[kind] {
+			name := nameOf(r)
+			if plan.ambiguous[kind][name] {
+				return nil, fmt.Errorf("config: %s %q is ambiguous, several of them exist in the account", kind, name)
+			}
+
+			resolved, err := p.resolve(ctx, plan, r, false)
+			if err != nil {
+				return nil, fmt.Errorf("config: %s %q: %w", kind, name, err)
+			}
+
+			current, ok := account[kind][name]
+			if !ok {
+				plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Kind: kind, Name: name, resource: r})
+				continue
+			}
+
+			id := plan.ids[kind][name]
+			if kind == KindSchedule {
+				// listed schedules lack their layers
+				current, _, err = p.client.Schedules.GetContext(ctx, id)
+				if err != nil {
+					return nil, fmt.Errorf("config: fetching schedule %q: %w", name, err)
+				}
+			}
+
+			diffs, err := diff(resolved, current)
+			if err != nil {
+				return nil, fmt.Errorf("config: %s %q: %w", kind, name, err)
+			}
+			if len(diffs) > 0 {
+				plan.Changes = append(plan.Changes, Change{
+					Action:   ActionUpdate,
+					Kind:     kind,
+					Name:     name,
+					ID:       id,
+					Diffs:    diffs,
+					resource: r,
+					current:  current,
+				})
+			}
+		}
+	}
+
+	if !p.Prune {
+		return plan, nil
+	}
+
+	// resources are deleted once nothing references them anymore, in reverse
+	// dependency order
+	for i := len(kinds) - 1; i >= 0; i-- {
+		kind := kinds[i]
+		if len(desired[kind]) == 0 {
+			continue
+		}
+
+		var names []string
+		for name := range account[kind] {
+			if !plan.defined[kind][name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Kind: kind, Name: name, ID: plan.ids[kind][name]})
+		}
+	}
+
+	return plan, nil
+}
+
+func nameOf(r interface{}) string {
+	var name *string
+	switch r := r.(type) {
+	case *pagerduty.Team:
+		name = r.Name
+	case *pagerduty.Schedule:
+		name = r.Name
+	case *pagerduty.EscalationPolicy:
+		name = r.Name
+	case *pagerduty.Service:
+		name = r.Name
+	}
+
+	if name == nil {
+		return ""
+	}
+	return *name
+}
+
+// resolve returns a copy of the resource r of the configuration with its
+// references replaced with the ids of what they reference. While planning,
+// the resources yet to be created are referenced as known after apply, while
+// applying, final is set and they must exist.
+func (p *Planner) resolve(ctx context.Context, plan *Plan, r interface{}, final bool) (interface{}, error) {
+	switch r := r.(type) {
+	case *pagerduty.Team:
+		t := *r
+		t.Members = nil
+		return &t, nil
+
+	case *pagerduty.Schedule:
+		s := *r
+		s.ScheduleLayers = make([]pagerduty.ScheduleLayer, len(r.ScheduleLayers))
+		for i, layer := range r.ScheduleLayers {
+			users := make([]pagerduty.User, len(layer.Users))
+			for j, u := range layer.Users {
+				id, err := p.userID(ctx, plan, u.ID, u.Email)
+				if err != nil {
+					return nil, err
+				}
+				users[j] = pagerduty.User{ID: pagerduty.String(id)}
+			}
+
+			layer.Users = users
+			s.ScheduleLayers[i] = layer
+		}
+		return &s, nil
+
+	case *pagerduty.EscalationPolicy:
+		ep := *r
+		ep.EscalationRules = make([]pagerduty.EscalationRule, len(r.EscalationRules))
+		for i, rule := range r.EscalationRules {
+			targets := make([]pagerduty.Target, len(rule.Targets))
+			for j, t := range rule.Targets {
+				var id string
+				var err error
+				if targetType(t) == pagerduty.TargetTypeUser {
+					id, err = p.userID(ctx, plan, t.ID, t.Email)
+				} else {
+					id, err = plan.ref(KindSchedule, t.ID, t.Name, final)
+				}
+				if err != nil {
+					return nil, err
+				}
+
+				targets[j] = pagerduty.Target{ID: pagerduty.String(id), Type: pagerduty.String(targetType(t))}
+			}
+
+			rule.Targets = targets
+			ep.EscalationRules[i] = rule
+		}
+		return &ep, nil
+
+	case *pagerduty.Service:
+		s := *r
+		id, err := plan.ref(KindEscalationPolicy, r.EscalationPolicy.ID, r.EscalationPolicy.Name, final)
+		if err != nil {
+			return nil, err
+		}
+		s.EscalationPolicy = &pagerduty.EscalationPolicy{ID: pagerduty.String(id)}
+		return &s, nil
+	}
+
+	return nil, fmt.Errorf("unsupported resource %T", r)
+}
+
+// ref returns the id of the resource of kind referenced by id or name.
+func (p *Plan) ref(kind Kind, id, name *string, final bool) (string, error) {
+	if !isEmpty(id) {
+		return *id, nil
+	}
+
+	if p.ambiguous[kind][*name] {
+		return "", fmt.Errorf("%s %q is ambiguous, several of them exist in the account", kind, *name)
+	}
+	if id, ok := p.ids[kind][*name]; ok {
+		return id, nil
+	}
+	if p.defined[kind][*name] && !final {
+		return knownAfterApply, nil
+	}
+
+	return "", fmt.Errorf("unknown %s %q", kind, *name)
+}
+
+// userID returns the id of the user referenced by id or email address.
+func (p *Planner) userID(ctx context.Context, plan *Plan, id, email *string) (string, error) {
+	if !isEmpty(id) {
+		return *id, nil
+	}
+
+	if plan.users == nil {
+		users, err := p.client.Users.ListAllContext(ctx, nil, nil)
+		if err != nil {
+			return "", fmt.Errorf("listing users: %w", err)
+		}
+
+		plan.users = make(map[string]string)
+		for _, u := range users {
+			if u.ID != nil && u.Email != nil {
+				plan.users[strings.ToLower(*u.Email)] = *u.ID
+			}
+		}
+	}
+
+	if id, ok := plan.users[strings.ToLower(*email)]; ok {
+		return id, nil
+	}
+
+	return "", fmt.Errorf("unknown user %q", *email)
+}
+
+// diff returns the fields of desired differing from current. Only the fields
+// set in desired are compared, so that the fields the API adds, such as ids
+// and summaries, are ignored.
+func diff(desired, current interface{}) ([]Diff, error) {
+	want, err := jsonValue(desired)
+	if err != nil {
+		return nil, err
+	}
+	have, err := jsonValue(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []Diff
+	compare("", "", want, have, &diffs)
+	return diffs, nil
+}
+
+func jsonValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+func compare(path, key string, want, have interface{}, diffs *[]Diff) {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		if h, ok := have.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(w))
+			for k := range w {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				p := k
+				if path != "" {
+					p = path + "." + k
+				}
+				compare(p, k, w[k], h[k], diffs)
+			}
+			return
+		}
+
+	case []interface{}:
+		if h, ok := have.([]interface{}); ok && len(h) == len(w) {
+			for i := range w {
+				compare(fmt.Sprintf("%s[%d]", path, i), key, w[i], h[i], diffs)
+			}
+			return
+		}
+
+	default:
+		if equal(key, want, have) {
+			return
+		}
+	}
+
+	*diffs = append(*diffs, Diff{Field: path, From: format(have), To: format(want)})
+}
+
+// equal reports whether the scalar values of a field are equal, considering
+// the types of references and plain objects alike, and timestamps in
+// different time zones alike.
+func equal(key string, want, have interface{}) bool {
+	if reflect.DeepEqual(want, have) {
+		return true
+	}
+
+	w, ok := want.(string)
+	if !ok {
+		return false
+	}
+	h, ok := have.(string)
+	if !ok {
+		return false
+	}
+
+	if key == "type" {
+		return strings.TrimSuffix(w, "_reference") == strings.TrimSuffix(h, "_reference")
+	}
+
+	wt, err := time.Parse(time.RFC3339, w)
+	if err != nil {
+		return false
+	}
+	ht, err := time.Parse(time.RFC3339, h)
+	return err == nil && wt.Equal(ht)
+}
+
+func format(v interface{}) string {
+	if v == knownAfterApply {
+		return knownAfterApply
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
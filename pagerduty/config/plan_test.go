@@ -0,0 +1,202 @@
+package config_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const (
+	teamsJSON = `{"more": false, "teams": [
+		{"id": "PTEAM01", "name": "Platform", "description": "Old", "type": "team", "summary": "Platform"}
+	]}`
+	schedulesJSON = `{"more": false, "schedules": [
+		{"id": "PSCHED1", "name": "Primary", "time_zone": "UTC"},
+		{"id": "PSCHED9", "name": "Legacy", "time_zone": "UTC"}
+	]}`
+	primaryJSON = `{"schedule": {
+		"id": "PSCHED1",
+		"name": "Primary",
+		"time_zone": "UTC",
+		"schedule_layers": [{
+			"id": "PLAYER1",
+			"name": "Weekly",
+			"start": "2015-11-07T01:00:00Z",
+			"rotation_virtual_start": "2015-11-07T01:00:00Z",
+			"rotation_turn_length_seconds": 86400,
+			"users": [{"user": {"id": "PALICE1", "type": "user_reference", "summary": "Alice"}}]
+		}]
+	}}`
+	policiesJSON = `{"more": false, "escalation_policies": [{
+		"id": "PEP0001",
+		"name": "Default",
+		"num_loops": 0,
+		"escalation_rules": [{
+			"id": "PRULE01",
+			"escalation_delay_in_minutes": 15,
+			"targets": [{"id": "PSCHED1", "type": "schedule_reference"}]
+		}]
+	}]}`
+	servicesJSON = `{"more": false, "services": [
+		{"id": "PSVC001", "name": "API", "escalation_policy": {"id": "PEP0001", "type": "escalation_policy_reference"}},
+		{"id": "PSVC009", "name": "Legacy", "escalation_policy": {"id": "PEP0001", "type": "escalation_policy_reference"}}
+	]}`
+	usersJSON = `{"more": false, "users": [
+		{"id": "PALICE1", "name": "Alice", "email": "alice@example.com"}
+	]}`
+)
+
+// newAccount returns a server serving the resources of an account, and a
+// client talking to it.
+func newAccount() (*ghttp.Server, *pagerduty.Client) {
+	server := ghttp.NewServer()
+	client := pagerduty.NewClientV2(nil, "super-secret-key", "user@example.com")
+	client.BaseURL, _ = url.Parse(server.URL())
+
+	for path, body := range map[string]string{
+		"/teams":               teamsJSON,
+		"/schedules":           schedulesJSON,
+		"/schedules/PSCHED1":   primaryJSON,
+		"/escalation_policies": policiesJSON,
+		"/services":            servicesJSON,
+		"/users":               usersJSON,
+	} {
+		server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, body))
+	}
+
+	return server, client
+}
+
+var _ = Describe("Planner", func() {
+	var (
+		server  *ghttp.Server
+		planner *Planner
+		cfg     *Config
+
+		plan *Plan
+		err  error
+	)
+
+	BeforeEach(func() {
+		var client *pagerduty.Client
+		server, client = newAccount()
+		planner = NewPlanner(client)
+		planner.Prune = true
+
+		cfg, err = Parse([]byte(configYAML))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() { server.Close() })
+
+	JustBeforeEach(func() {
+		plan, err = planner.Plan(context.Background(), cfg)
+	})
+
+	It("should not return an error", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should plan the changes in dependency order", func() {
+		var buf bytes.Buffer
+		_, err := plan.WriteTo(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(Equal(`~ update team "Platform" (PTEAM01)
+    description: "Old" -> "Platform team"
+~ update schedule "Primary" (PSCHED1)
+    schedule_layers[0].rotation_turn_length_seconds: 86400 -> 604800
++ create schedule "Secondary"
+~ update escalation policy "Default" (PEP0001)
+    escalation_rules[0].escalation_delay_in_minutes: 15 -> 30
+    escalation_rules[0].targets[0].id: "PSCHED1" -> (known after apply)
+- delete service "Legacy" (PSVC009)
+- delete schedule "Legacy" (PSCHED9)
+
+Plan: 1 to create, 3 to update, 2 to delete.
+`))
+	})
+
+	It("should describe the changes", func() {
+		c := plan.Changes[0]
+		Expect(c.Action).To(Equal(ActionUpdate))
+		Expect(c.Kind).To(Equal(KindTeam))
+		Expect(c.Name).To(Equal("Platform"))
+		Expect(c.ID).To(Equal("PTEAM01"))
+		Expect(c.Diffs).To(Equal([]Diff{{Field: "description", From: `"Old"`, To: `"Platform team"`}}))
+	})
+
+	Context("without pruning", func() {
+		BeforeEach(func() { planner.Prune = false })
+
+		It("should leave the resources missing from the configuration untouched", func() {
+			Expect(plan.Changes).To(HaveLen(4))
+			for _, c := range plan.Changes {
+				Expect(c.Action).NotTo(Equal(ActionDelete))
+			}
+		})
+	})
+
+	Context("when pruning a configuration without services", func() {
+		BeforeEach(func() { cfg.Services = nil })
+
+		It("should leave the services untouched", func() {
+			for _, c := range plan.Changes {
+				Expect(c.Kind).NotTo(Equal(KindService))
+			}
+		})
+	})
+
+	Context("when the account matches the configuration", func() {
+		BeforeEach(func() {
+			planner.Prune = false
+			cfg = &Config{
+				Teams: []pagerduty.Team{{Name: pagerduty.String("Platform"), Description: pagerduty.String("Old")}},
+				Services: []pagerduty.Service{{
+					Name:             pagerduty.String("API"),
+					EscalationPolicy: &pagerduty.EscalationPolicy{Name: pagerduty.String("Default")},
+				}},
+			}
+		})
+
+		It("should return an empty plan", func() {
+			Expect(plan.Empty()).To(BeTrue())
+
+			var buf bytes.Buffer
+			plan.WriteTo(&buf)
+			Expect(buf.String()).To(Equal("No changes. The account matches the configuration.\n"))
+		})
+	})
+
+	Context("with a reference to an unknown resource", func() {
+		BeforeEach(func() { cfg.Services[0].EscalationPolicy.Name = pagerduty.String("Missing") })
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(`config: service "API": unknown escalation policy "Missing"`))
+		})
+	})
+
+	Context("with a reference to an unknown user", func() {
+		BeforeEach(func() { cfg.Schedules[0].ScheduleLayers[0].Users[0].Email = pagerduty.String("erin@example.com") })
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(`config: schedule "Primary": unknown user "erin@example.com"`))
+		})
+	})
+
+	Context("with an invalid configuration", func() {
+		BeforeEach(func() { cfg.Teams[0].Name = nil })
+
+		It("should return an error before reading the account", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
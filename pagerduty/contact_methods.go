@@ -0,0 +1,174 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+)
+
+// Types of contact methods in the REST API v1, and their REST API v2
+// equivalents.
+const (
+	ContactMethodTypeEmail = "email"
+	ContactMethodTypePhone = "phone"
+	ContactMethodTypeSMS   = "SMS"
+	ContactMethodTypePush  = "push_notification"
+
+	ObjectTypeEmailContactMethod = "email_contact_method"
+	ObjectTypePhoneContactMethod = "phone_contact_method"
+	ObjectTypeSMSContactMethod   = "sms_contact_method"
+	ObjectTypePushContactMethod  = "push_notification_contact_method"
+)
+
+// contactMethodTypesV2 maps the types of contact methods in the REST API v1
+// to their REST API v2 equivalents.
+var contactMethodTypesV2 = map[string]string{
+	ContactMethodTypeEmail: ObjectTypeEmailContactMethod,
+	ContactMethodTypePhone: ObjectTypePhoneContactMethod,
+	ContactMethodTypeSMS:   ObjectTypeSMSContactMethod,
+	ContactMethodTypePush:  ObjectTypePushContactMethod,
+}
+
+// ContactMethod represents a way of contacting a user, such as an email
+// address or a phone number.
+type ContactMethod struct {
+	ID             *string `json:"id,omitempty"`
+	Label          *string `json:"label,omitempty"`
+	Address        *string `json:"address,omitempty"`
+	CountryCode    *int    `json:"country_code,omitempty"`
+	SendShortEmail *bool   `json:"send_short_email,omitempty"`
+	Blacklisted    *bool   `json:"blacklisted,omitempty"`
+
+	// REST API v1 only fields.
+	UserID      *string `json:"user_id,omitempty"`
+	PhoneNumber *string `json:"phone_number,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+
+	APIObject
+}
+
+type contactMethodListWrapper struct {
+	ContactMethods []ContactMethod `json:"contact_methods"`
+}
+
+type contactMethodWrapper struct {
+	ContactMethod *ContactMethod `json:"contact_method"`
+}
+
+// contactMethodBody returns the request body creating or updating method. The
+// REST API v2 names the types of contact methods differently.
+func (s *UsersService) contactMethodBody(method *ContactMethod) *contactMethodWrapper {
+	m := *method
+	if s.client.isV2() && m.Type != nil {
+		if t, ok := contactMethodTypesV2[*m.Type]; ok {
+			m.Type = String(t)
+		}
+	}
+
+	return &contactMethodWrapper{ContactMethod: &m}
+}
+
+// ListContactMethods fetches the contact methods of a user.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/contact_methods/list
+func (s *UsersService) ListContactMethods(userID string) ([]ContactMethod, *Response, error) {
+	return s.ListContactMethodsContext(context.Background(), userID)
+}
+
+// ListContactMethodsContext is the context aware variant of ListContactMethods.
+func (s *UsersService) ListContactMethodsContext(ctx context.Context, userID string) ([]ContactMethod, *Response, error) {
+	uri := fmt.Sprintf("users/%s/contact_methods", userID)
+
+	methods := new(contactMethodListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, methods)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return methods.ContactMethods, resp, err
+}
+
+// GetContactMethod fetches a contact method of a user by id.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/contact_methods/show
+func (s *UsersService) GetContactMethod(userID, id string) (*ContactMethod, *Response, error) {
+	return s.GetContactMethodContext(context.Background(), userID, id)
+}
+
+// GetContactMethodContext is the context aware variant of GetContactMethod.
+func (s *UsersService) GetContactMethodContext(ctx context.Context, userID, id string) (*ContactMethod, *Response, error) {
+	uri := fmt.Sprintf("users/%s/contact_methods/%s", userID, id)
+
+	method := new(contactMethodWrapper)
+	resp, err := s.client.GetContext(ctx, uri, method)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return method.ContactMethod, resp, err
+}
+
+// CreateContactMethod adds a contact method to a user. Its type and address
+// are required, as well as the country code of phone numbers.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/contact_methods/create
+func (s *UsersService) CreateContactMethod(userID string, method *ContactMethod) (*ContactMethod, *Response, error) {
+	return s.CreateContactMethodContext(context.Background(), userID, method)
+}
+
+// CreateContactMethodContext is the context aware variant of
+// CreateContactMethod.
+func (s *UsersService) CreateContactMethodContext(ctx context.Context, userID string, method *ContactMethod) (*ContactMethod, *Response, error) {
+	if method == nil {
+		return nil, nil, fmt.Errorf("pagerduty: contact method cannot be nil")
+	}
+
+	uri := fmt.Sprintf("users/%s/contact_methods", userID)
+
+	m := new(contactMethodWrapper)
+	resp, err := s.client.PostContext(ctx, uri, s.contactMethodBody(method), m)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return m.ContactMethod, resp, err
+}
+
+// UpdateContactMethod updates an existing contact method of a user.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/contact_methods/update
+func (s *UsersService) UpdateContactMethod(userID string, method *ContactMethod) (*ContactMethod, *Response, error) {
+	return s.UpdateContactMethodContext(context.Background(), userID, method)
+}
+
+// UpdateContactMethodContext is the context aware variant of
+// UpdateContactMethod.
+func (s *UsersService) UpdateContactMethodContext(ctx context.Context, userID string, method *ContactMethod) (*ContactMethod, *Response, error) {
+	if method == nil || method.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: contact method id cannot be nil")
+	}
+
+	uri := fmt.Sprintf("users/%s/contact_methods/%s", userID, *method.ID)
+
+	m := new(contactMethodWrapper)
+	resp, err := s.client.PutContext(ctx, uri, s.contactMethodBody(method), m)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return m.ContactMethod, resp, err
+}
+
+// DeleteContactMethod removes a contact method from a user, along with the
+// notification rules using it.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/contact_methods/delete
+func (s *UsersService) DeleteContactMethod(userID, id string) (*Response, error) {
+	return s.DeleteContactMethodContext(context.Background(), userID, id)
+}
+
+// DeleteContactMethodContext is the context aware variant of
+// DeleteContactMethod.
+func (s *UsersService) DeleteContactMethodContext(ctx context.Context, userID, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("users/%s/contact_methods/%s", userID, id))
+}
@@ -0,0 +1,222 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	contactMethodListJSON = `{ "contact_methods": [` + contactMethodJSON + `]}`
+	contactMethodGetJSON  = `{ "contact_method": ` + contactMethodJSON + `}`
+	contactMethodJSON     = `{
+		"id": "PDBIBUJ",
+		"type": "email",
+		"label": "Work",
+		"address": "betty@example.com",
+		"user_id": "PXPGF42",
+		"email": "betty@example.com",
+		"send_short_email": false,
+		"blacklisted": false,
+		"enabled": true
+	}`
+)
+
+var _ = Describe("ContactMethods", func() {
+	var (
+		env                   *TestEnvironment
+		expectedContactMethod ContactMethod
+	)
+
+	json.Unmarshal([]byte(contactMethodJSON), &expectedContactMethod)
+
+	BeforeEach(func() { env = NewTestEnvironment() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("ListContactMethods", func() {
+		Context("with a successful, non-empty response", func() {
+			var (
+				methods []ContactMethod
+				resp    *Response
+				err     error
+			)
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/users/PXPGF42/contact_methods", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, contactMethodListJSON),
+				))
+
+				methods, resp, err = env.Client.Users.ListContactMethods("PXPGF42")
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the expected contact methods", func() {
+				Expect(methods).To(Equal([]ContactMethod{expectedContactMethod}))
+				Expect(*methods[0].Type).To(Equal(ContactMethodTypeEmail))
+			})
+		})
+	})
+
+	Describe("GetContactMethod", func() {
+		It("should return the expected contact method", func() {
+			env.Server.RouteToHandler(GET, "/users/PXPGF42/contact_methods/PDBIBUJ", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusOK, contactMethodGetJSON),
+			))
+
+			method, _, err := env.Client.Users.GetContactMethod("PXPGF42", "PDBIBUJ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(method).To(Equal(&expectedContactMethod))
+		})
+	})
+
+	Describe("CreateContactMethod", func() {
+		var (
+			method *ContactMethod
+			resp   *Response
+			err    error
+		)
+
+		newMethod := func() *ContactMethod {
+			return &ContactMethod{
+				Address:     String("5555550100"),
+				CountryCode: Int(1),
+				Label:       String("Mobile"),
+				APIObject:   APIObject{Type: String(ContactMethodTypeSMS)},
+			}
+		}
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/users/PXPGF42/contact_methods", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"contact_method": {
+							"type": "SMS",
+							"address": "5555550100",
+							"country_code": 1,
+							"label": "Mobile"
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, contactMethodGetJSON),
+				))
+
+				method, resp, err = env.Client.Users.CreateContactMethod("PXPGF42", newMethod())
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the created contact method", func() {
+				Expect(method).To(Equal(&expectedContactMethod))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/users/PXPGF42/contact_methods", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"contact_method": {
+							"type": "sms_contact_method",
+							"address": "5555550100",
+							"country_code": 1,
+							"label": "Mobile"
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, contactMethodGetJSON),
+				))
+
+				method, resp, err = env.Client.Users.CreateContactMethod("PXPGF42", newMethod())
+			})
+
+			It("should send the REST API v2 type of the contact method", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("without a contact method", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Users.CreateContactMethod("PXPGF42", nil)
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("UpdateContactMethod", func() {
+		var err error
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/users/PXPGF42/contact_methods/PDBIBUJ", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.VerifyJSON(`{ "contact_method": { "id": "PDBIBUJ", "label": "Work" } }`),
+					ghttp.RespondWith(http.StatusOK, contactMethodGetJSON),
+				))
+
+				_, _, err = env.Client.Users.UpdateContactMethod("PXPGF42", &ContactMethod{
+					ID:    String("PDBIBUJ"),
+					Label: String("Work"),
+				})
+			})
+
+			It("should update the contact method", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("without an id", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Users.UpdateContactMethod("PXPGF42", &ContactMethod{Label: String("Work")})
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteContactMethod", func() {
+		It("should delete the contact method", func() {
+			env.Server.RouteToHandler(DELETE, "/users/PXPGF42/contact_methods/PDBIBUJ", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.Users.DeleteContactMethod("PXPGF42", "PDBIBUJ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
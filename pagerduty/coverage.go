@@ -0,0 +1,305 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CoverageInterval is a span of time in a coverage analysis.
+type CoverageInterval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// The users on call during the interval, for double-booked intervals, by
+	// decreasing layer priority.
+	Users []User `json:"users,omitempty"`
+}
+
+// Duration returns the length of the interval.
+func (i CoverageInterval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// ScheduleCoverage is the coverage of a schedule over a range of time.
+type ScheduleCoverage struct {
+	ScheduleID string    `json:"schedule_id,omitempty"`
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+
+	// The intervals during which nobody is on call.
+	Gaps []CoverageInterval `json:"gaps,omitempty"`
+
+	// The intervals during which several layers put different users on call.
+	// Only the user of the layer of highest priority is notified, unless an
+	// override replaces them.
+	Overlaps []CoverageInterval `json:"overlaps,omitempty"`
+}
+
+// Percentage returns the percentage of the range during which someone is on
+// call.
+func (c *ScheduleCoverage) Percentage() float64 {
+	total := c.Until.Sub(c.Since)
+	if total <= 0 {
+		return 0
+	}
+
+	return 100 * float64(total-sumDurations(c.Gaps)) / float64(total)
+}
+
+// Complete reports whether someone is on call during the whole range.
+func (c *ScheduleCoverage) Complete() bool {
+	return len(c.Gaps) == 0
+}
+
+func sumDurations(intervals []CoverageInterval) time.Duration {
+	var d time.Duration
+	for _, i := range intervals {
+		d += i.Duration()
+	}
+
+	return d
+}
+
+// Coverage analyses who is on call on the schedule between since and until,
+// computed offline like Render with the given overrides, reporting the
+// intervals during which nobody is on call and the ones during which several
+// layers put different users on call.
+func (s *Schedule) Coverage(since, until time.Time, overrides []Override) (*ScheduleCoverage, error) {
+	if !since.Before(until) {
+		return nil, fmt.Errorf("pagerduty: coverage range must end after it starts")
+	}
+
+	layers, loc, err := s.rotations()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ScheduleCoverage{Since: since.In(loc), Until: until.In(loc)}
+	if s.ID != nil {
+		c.ScheduleID = *s.ID
+	}
+
+	// extend appends the interval from start to end to intervals, merging it
+	// with the last one when they are contiguous and have the same users
+	extend := func(intervals []CoverageInterval, start, end time.Time, users []User) []CoverageInterval {
+		if n := len(intervals); n > 0 && intervals[n-1].End.Equal(start) && sameUsers(intervals[n-1].Users, users) {
+			intervals[n-1].End = end.In(loc)
+			return intervals
+		}
+
+		return append(intervals, CoverageInterval{Start: start.In(loc), End: end.In(loc), Users: users})
+	}
+
+	points := changePoints(since, until, layers, overrides)
+	for i := 0; i < len(points)-1; i++ {
+		start, end := points[i], points[i+1]
+		if !start.Before(end) {
+			continue
+		}
+
+		var users []User
+		seen := make(map[string]bool)
+		for _, r := range layers {
+			if u := r.userAt(start); u != nil && !seen[userKey(u)] {
+				seen[userKey(u)] = true
+				users = append(users, *u)
+			}
+		}
+
+		if len(users) == 0 && overrideAt(start, overrides) == nil {
+			c.Gaps = extend(c.Gaps, start, end, nil)
+		}
+		if len(users) > 1 {
+			c.Overlaps = extend(c.Overlaps, start, end, users)
+		}
+	}
+
+	return c, nil
+}
+
+func sameUsers(a, b []User) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if userKey(&a[i]) != userKey(&b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EscalationPolicyCoverage is the coverage of the rules of an escalation
+// policy over a range of time.
+type EscalationPolicyCoverage struct {
+	EscalationPolicyID string    `json:"escalation_policy_id,omitempty"`
+	Since              time.Time `json:"since"`
+	Until              time.Time `json:"until"`
+
+	// The coverage of the schedules targeted by the rules, keyed by id.
+	Schedules map[string]*ScheduleCoverage `json:"schedules,omitempty"`
+
+	// The coverage of the rules, in the order of the policy.
+	Rules []EscalationRuleCoverage `json:"rules"`
+}
+
+// EscalationRuleCoverage is the coverage of an escalation rule.
+type EscalationRuleCoverage struct {
+	// The position of the rule in its policy.
+	Index  int     `json:"index"`
+	RuleID *string `json:"rule_id,omitempty"`
+
+	// The intervals during which none of the targets of the rule has someone
+	// on call. Users are always on call.
+	Gaps []CoverageInterval `json:"gaps,omitempty"`
+
+	// Whether none of the targets of the rule has anyone on call during the
+	// whole range.
+	Uncovered bool `json:"uncovered"`
+}
+
+// UncoveredRules returns the coverage of the rules none of whose targets has
+// anyone on call during the whole range.
+func (c *EscalationPolicyCoverage) UncoveredRules() []EscalationRuleCoverage {
+	var rules []EscalationRuleCoverage
+	for _, r := range c.Rules {
+		if r.Uncovered {
+			rules = append(rules, r)
+		}
+	}
+
+	return rules
+}
+
+// Complete reports whether every rule has someone on call during the whole
+// range.
+func (c *EscalationPolicyCoverage) Complete() bool {
+	for _, r := range c.Rules {
+		if len(r.Gaps) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Coverage analyses the coverage of the rules of the policy between since and
+// until from the coverage of the schedules they target, keyed by schedule id.
+// Targeted schedules missing from schedules are taken as never covered.
+func (p *EscalationPolicy) Coverage(since, until time.Time, schedules map[string]*ScheduleCoverage) *EscalationPolicyCoverage {
+	c := &EscalationPolicyCoverage{Since: since, Until: until, Schedules: schedules}
+	if p.ID != nil {
+		c.EscalationPolicyID = *p.ID
+	}
+
+	whole := []CoverageInterval{{Start: since, End: until}}
+	for i, rule := range p.EscalationRules {
+		gaps := whole
+		for _, target := range rule.Targets {
+			if target.ID == nil || target.Type == nil {
+				continue
+			}
+
+			switch *target.Type {
+			case TargetTypeUser, ObjectTypeUserReference:
+				gaps = nil
+			case TargetTypeSchedule, ObjectTypeScheduleReference:
+				if sc, ok := schedules[*target.ID]; ok {
+					gaps = intersectIntervals(gaps, sc.Gaps)
+				}
+			}
+		}
+
+		c.Rules = append(c.Rules, EscalationRuleCoverage{
+			Index:     i,
+			RuleID:    rule.ID,
+			Gaps:      gaps,
+			Uncovered: sumDurations(gaps) >= until.Sub(since),
+		})
+	}
+
+	return c
+}
+
+// intersectIntervals returns the intervals during which both a and b, sorted
+// lists of disjoint intervals, have an interval.
+func intersectIntervals(a, b []CoverageInterval) []CoverageInterval {
+	var intervals []CoverageInterval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].Start, a[i].End
+		if b[j].Start.After(start) {
+			start = b[j].Start
+		}
+		if b[j].End.Before(end) {
+			end = b[j].End
+		}
+		if start.Before(end) {
+			intervals = append(intervals, CoverageInterval{Start: start, End: end})
+		}
+
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return intervals
+}
+
+// Coverage fetches a schedule and its overrides, and analyses its coverage
+// between since and until.
+func (s *SchedulesService) Coverage(id string, since, until time.Time) (*ScheduleCoverage, *Response, error) {
+	return s.CoverageContext(context.Background(), id, since, until)
+}
+
+// CoverageContext is the context aware variant of Coverage.
+func (s *SchedulesService) CoverageContext(ctx context.Context, id string, since, until time.Time) (*ScheduleCoverage, *Response, error) {
+	schedule, resp, err := s.GetContext(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	overrides, resp, err := s.ListOverridesContext(ctx, id, &ScheduleOverridesOptions{Since: since, Until: until})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	c, err := schedule.Coverage(since, until, overrides)
+	return c, resp, err
+}
+
+// Coverage fetches an escalation policy and the schedules its rules target,
+// and analyses the coverage of the rules between since and until.
+func (s *EscalationPoliciesService) Coverage(id string, since, until time.Time) (*EscalationPolicyCoverage, *Response, error) {
+	return s.CoverageContext(context.Background(), id, since, until)
+}
+
+// CoverageContext is the context aware variant of Coverage.
+func (s *EscalationPoliciesService) CoverageContext(ctx context.Context, id string, since, until time.Time) (*EscalationPolicyCoverage, *Response, error) {
+	policy, resp, err := s.GetContext(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	schedules := make(map[string]*ScheduleCoverage)
+	for _, rule := range policy.EscalationRules {
+		for _, target := range rule.Targets {
+			if target.ID == nil || target.Type == nil || schedules[*target.ID] != nil ||
+				(*target.Type != TargetTypeSchedule && *target.Type != ObjectTypeScheduleReference) {
+				continue
+			}
+
+			var c *ScheduleCoverage
+			c, resp, err = s.client.Schedules.CoverageContext(ctx, *target.ID, since, until)
+			if err != nil {
+				return nil, resp, err
+			}
+			schedules[*target.ID] = c
+		}
+	}
+
+	return policy.Coverage(since, until, schedules), resp, nil
+}
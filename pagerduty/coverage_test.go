@@ -0,0 +1,260 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"net/http"
+	"time"
+)
+
+const (
+	coveragePolicyGetJSON = `{"escalation_policy": {
+		"id": "PCOVEP1",
+		"name": "Coverage",
+		"escalation_rules": [
+			{
+				"id": "PRULE01",
+				"escalation_delay_in_minutes": 30,
+				"targets": [{"id": "PBUSINE", "type": "schedule"}]
+			},
+			{
+				"id": "PRULE02",
+				"escalation_delay_in_minutes": 30,
+				"targets": [{"id": "PENDED1", "type": "schedule"}]
+			}
+		]
+	}}`
+	coverageBusinessScheduleJSON = `{"schedule": {
+		"id": "PBUSINE",
+		"time_zone": "UTC",
+		"schedule_layers": [{
+			"start": "2015-11-01T00:00:00Z",
+			"users": [{"user": {"id": "PALICE1"}}],
+			"restriction_type": "daily_restriction",
+			"restrictions": [{
+				"type": "daily_restriction",
+				"start_time_of_day": "09:00:00",
+				"duration_seconds": 28800
+			}]
+		}]
+	}}`
+	coverageEndedScheduleJSON = `{"schedule": {
+		"id": "PENDED1",
+		"time_zone": "UTC",
+		"schedule_layers": [{
+			"start": "2015-01-01T00:00:00Z",
+			"end": "2015-02-01T00:00:00Z",
+			"users": [{"user": {"id": "PBOB001"}}]
+		}]
+	}}`
+	coverageOverridesJSON = `{"overrides": []}`
+)
+
+var _ = Describe("Coverage", func() {
+	var (
+		alice = User{ID: String("PALICE1")}
+		bob   = User{ID: String("PBOB001")}
+
+		// a Monday
+		since = time.Date(2015, time.November, 9, 0, 0, 0, 0, time.UTC)
+		until = since.Add(24 * time.Hour)
+		nine  = since.Add(9 * time.Hour)
+		five  = since.Add(17 * time.Hour)
+
+		schedule *Schedule
+		coverage *ScheduleCoverage
+		err      error
+	)
+
+	interval := func(from, to time.Time, users ...User) CoverageInterval {
+		return CoverageInterval{Start: from, End: to, Users: users}
+	}
+
+	BeforeEach(func() {
+		schedule = &Schedule{
+			ID: String("PBUSINE"),
+			ScheduleLayers: []ScheduleLayer{{
+				Start:        &Date{&since},
+				Users:        []User{alice},
+				Restrictions: []Restriction{NewDailyRestriction(TimeOfDay{Hour: 9}, 8*time.Hour)},
+			}},
+		}
+	})
+
+	Describe("Schedule.Coverage", func() {
+		Context("with a restricted layer", func() {
+			BeforeEach(func() {
+				coverage, err = schedule.Coverage(since, until, nil)
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should report the uncovered intervals", func() {
+				Expect(coverage.ScheduleID).To(Equal("PBUSINE"))
+				Expect(coverage.Gaps).To(Equal([]CoverageInterval{
+					interval(since, nine),
+					interval(five, until),
+				}))
+				Expect(coverage.Complete()).To(BeFalse())
+				Expect(coverage.Percentage()).To(BeNumerically("~", 100.0/3, 0.001))
+			})
+
+			It("should report no overlap", func() {
+				Expect(coverage.Overlaps).To(BeEmpty())
+			})
+		})
+
+		Context("with overrides filling a gap", func() {
+			BeforeEach(func() {
+				overrides := []Override{{Start: Time(since), End: Time(nine), User: &bob}}
+				coverage, err = schedule.Coverage(since, until, overrides)
+			})
+
+			It("should not report the gap", func() {
+				Expect(coverage.Gaps).To(Equal([]CoverageInterval{interval(five, until)}))
+			})
+		})
+
+		Context("with layers putting different users on call", func() {
+			BeforeEach(func() {
+				schedule.ScheduleLayers = append(schedule.ScheduleLayers,
+					ScheduleLayer{Start: &Date{&since}, Users: []User{bob}},
+					ScheduleLayer{Start: &Date{&since}, Users: []User{alice}},
+				)
+				coverage, err = schedule.Coverage(since, until, nil)
+			})
+
+			It("should report the double-booked intervals", func() {
+				Expect(coverage.Complete()).To(BeTrue())
+				Expect(coverage.Percentage()).To(Equal(100.0))
+				Expect(coverage.Overlaps).To(Equal([]CoverageInterval{interval(since, until, alice, bob)}))
+			})
+		})
+
+		Context("with an empty range", func() {
+			It("should return an error", func() {
+				_, err = schedule.Coverage(since, since, nil)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("EscalationPolicy.Coverage", func() {
+		var (
+			policy         *EscalationPolicy
+			policyCoverage *EscalationPolicyCoverage
+		)
+
+		BeforeEach(func() {
+			coverage, _ = schedule.Coverage(since, until, nil)
+
+			night := &Schedule{
+				ID: String("PNIGHT1"),
+				ScheduleLayers: []ScheduleLayer{{
+					Start:        &Date{&since},
+					Users:        []User{bob},
+					Restrictions: NightRestrictions(TimeOfDay{Hour: 16}, TimeOfDay{Hour: 8}),
+				}},
+			}
+			nightCoverage, _ := night.Coverage(since, until, nil)
+
+			policy = &EscalationPolicy{
+				ID: String("PCOVEP1"),
+				EscalationRules: []EscalationRule{
+					{Targets: []Target{
+						{ID: String("PBUSINE"), Type: String(TargetTypeSchedule)},
+						{ID: String("PNIGHT1"), Type: String(ObjectTypeScheduleReference)},
+					}},
+					{Targets: []Target{
+						{ID: String("PBUSINE"), Type: String(TargetTypeSchedule)},
+						{ID: String("PALICE1"), Type: String(TargetTypeUser)},
+					}},
+					{Targets: []Target{{ID: String("PMISSIN"), Type: String(TargetTypeSchedule)}}},
+				},
+			}
+
+			policyCoverage = policy.Coverage(since, until, map[string]*ScheduleCoverage{
+				"PBUSINE": coverage,
+				"PNIGHT1": nightCoverage,
+			})
+		})
+
+		It("should report when none of the schedules of a rule has someone on call", func() {
+			Expect(policyCoverage.Rules).To(HaveLen(3))
+			Expect(policyCoverage.Rules[0].Gaps).To(Equal([]CoverageInterval{interval(since.Add(8*time.Hour), nine)}))
+			Expect(policyCoverage.Rules[0].Uncovered).To(BeFalse())
+		})
+
+		It("should consider users always on call", func() {
+			Expect(policyCoverage.Rules[1].Gaps).To(BeEmpty())
+		})
+
+		It("should report the rules whose targets have zero coverage", func() {
+			uncovered := policyCoverage.UncoveredRules()
+			Expect(uncovered).To(HaveLen(1))
+			Expect(uncovered[0].Index).To(Equal(2))
+			Expect(policyCoverage.Complete()).To(BeFalse())
+		})
+	})
+
+	Describe("EscalationPoliciesService.Coverage", func() {
+		var (
+			env            *TestEnvironment
+			policyCoverage *EscalationPolicyCoverage
+			resp           *Response
+		)
+
+		BeforeEach(func() {
+			env = NewTestEnvironment()
+
+			env.Server.RouteToHandler(GET, "/escalation_policies/PCOVEP1", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusOK, coveragePolicyGetJSON),
+			))
+			for id, body := range map[string]string{"PBUSINE": coverageBusinessScheduleJSON, "PENDED1": coverageEndedScheduleJSON} {
+				env.Server.RouteToHandler(GET, "/schedules/"+id, ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, body),
+				))
+				env.Server.RouteToHandler(GET, "/schedules/"+id+"/overrides", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, coverageOverridesJSON),
+				))
+			}
+
+			policyCoverage, resp, err = env.Client.EscalationPolicies.Coverage("PCOVEP1", since, until)
+		})
+
+		AfterEach(func() { env.Server.Close() })
+
+		It("should have fetched the policy, its schedules and their overrides", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(5))
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return a non-empty response", func() {
+			Expect(resp).NotTo(BeNil())
+		})
+
+		It("should return the coverage of the schedules", func() {
+			Expect(policyCoverage.Schedules).To(HaveLen(2))
+			Expect(policyCoverage.Schedules["PBUSINE"].Gaps).To(HaveLen(2))
+			Expect(policyCoverage.Schedules["PENDED1"].Percentage()).To(BeZero())
+		})
+
+		It("should return the coverage of the rules", func() {
+			Expect(policyCoverage.EscalationPolicyID).To(Equal("PCOVEP1"))
+			Expect(policyCoverage.Rules[0].Uncovered).To(BeFalse())
+			Expect(policyCoverage.Rules[1].Uncovered).To(BeTrue())
+		})
+	})
+})
@@ -1,14 +1,12 @@
 package pagerduty
 
-import (
-	"fmt"
-	"time"
-)
+import "time"
 
 const dateFormat = `"2006-01-02"`
 
 // Date is a thin wrapper around the time.Time type that parses dates of the
-// format 'YYYY-MM-DD'.
+// format 'YYYY-MM-DD'. Full RFC 3339 timestamps, such as the start of a
+// schedule layer, are accepted too and keep their time of day.
 type Date struct {
 	*time.Time
 }
@@ -18,16 +16,37 @@ func (d Date) String() string {
 }
 
 func (d *Date) MarshalJSON() ([]byte, error) {
-	str := fmt.Sprintf("%q", d.Time.Format(dateFormat))
-	return []byte(str), nil
+	if d.Time == nil {
+		return []byte("null"), nil
+	}
+
+	if !isDate(*d.Time) {
+		return d.Time.MarshalJSON()
+	}
+
+	return []byte(d.Time.Format(dateFormat)), nil
 }
 
 func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		d.Time = nil
+		return nil
+	}
+
 	date, err := time.Parse(dateFormat, string(data))
 	if err != nil {
-		return err
+		// fall back to a full timestamp
+		if err := date.UnmarshalJSON(data); err != nil {
+			return err
+		}
 	}
 
 	d.Time = &date
 	return nil
 }
+
+// isDate reports whether t is a bare date, i.e. midnight UTC.
+func isDate(t time.Time) bool {
+	h, m, s := t.Clock()
+	return t.Location() == time.UTC && h == 0 && m == 0 && s == 0 && t.Nanosecond() == 0
+}
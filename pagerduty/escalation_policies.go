@@ -1,9 +1,19 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 )
 
+const (
+	TargetTypeUser     = "user"
+	TargetTypeSchedule = "schedule"
+
+	// MaxEscalationPolicyLoops is the largest number of times an escalation
+	// policy can repeat its rules.
+	MaxEscalationPolicyLoops = 9
+)
+
 // EscalationPoliciesService handles communication with the Escalation Policies
 // related methods of the PagerDuty API.
 type EscalationPoliciesService struct {
@@ -17,14 +27,19 @@ type EscalationPolicy struct {
 	NumLoops        *int             `json:"num_loops,omitempty"`
 	EscalationRules []EscalationRule `json:"escalation_rules,omitempty"`
 	Services        []Service        `json:"services,omitempty"`
+
+	APIObject
 }
 
+// EscalationRule is a level of an escalation policy, notifying its targets
+// once the delay of the previous level elapsed.
 type EscalationRule struct {
 	ID                       *string  `json:"id,omitempty"`
 	EscalationDelayInMinutes *int     `json:"escalation_delay_in_minutes,omitempty"`
 	Targets                  []Target `json:"targets,omitempty"`
 }
 
+// Target is the user or schedule notified by an escalation rule.
 type Target struct {
 	ID       *string   `json:"id,omitempty"`
 	Type     *string   `json:"type,omitempty"`
@@ -46,6 +61,11 @@ type EscalationPolicyListOptions struct {
 	// Include extra information in the response. Possible values are 'teams'.
 	Include []string `url:"teams,omitempty"`
 
+	// REST API v2 filters, which replace the comma-separated Teams filter of
+	// the REST API v1.
+	UserIDs []string `url:"user_ids[],omitempty"`
+	TeamIDs []string `url:"team_ids[],omitempty"`
+
 	ListOptions
 }
 
@@ -57,13 +77,18 @@ type escalationPolicyListWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/escalation_policies/list
 func (s *EscalationPoliciesService) List(opts *EscalationPolicyListOptions) ([]EscalationPolicy, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *EscalationPoliciesService) ListContext(ctx context.Context, opts *EscalationPolicyListOptions) ([]EscalationPolicy, *Response, error) {
 	uri, err := addOptions("escalation_policies", opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	policies := new(escalationPolicyListWrapper)
-	resp, err := s.client.Get(uri, policies)
+	resp, err := s.client.GetContext(ctx, uri, policies)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -71,6 +96,44 @@ func (s *EscalationPoliciesService) List(opts *EscalationPolicyListOptions) ([]E
 	return policies.EscalationPolicies, resp, err
 }
 
+// ListAll fetches every escalation policy matching opts, following the pagination of List
+// until all of them or p.Max of them are collected.
+func (s *EscalationPoliciesService) ListAll(opts *EscalationPolicyListOptions, p *PaginationOptions) ([]EscalationPolicy, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *EscalationPoliciesService) ListAllContext(ctx context.Context, opts *EscalationPolicyListOptions, p *PaginationOptions) ([]EscalationPolicy, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every escalation policy matching opts.
+func (s *EscalationPoliciesService) Iter(opts *EscalationPolicyListOptions) *Iterator[EscalationPolicy] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *EscalationPoliciesService) IterContext(ctx context.Context, opts *EscalationPolicyListOptions) *Iterator[EscalationPolicy] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the escalation policies matching opts.
+func (s *EscalationPoliciesService) pager(opts *EscalationPolicyListOptions) (ListOptions, pageFetcher[EscalationPolicy]) {
+	var o EscalationPolicyListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]EscalationPolicy, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
+
 type escalationPolicyWrapper struct {
 	EscalationPolicy *EscalationPolicy `json:"escalation_policy"`
 }
@@ -79,13 +142,192 @@ type escalationPolicyWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/escalation_policies/show
 func (s *EscalationPoliciesService) Get(id string) (*EscalationPolicy, *Response, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *EscalationPoliciesService) GetContext(ctx context.Context, id string) (*EscalationPolicy, *Response, error) {
 	uri := fmt.Sprintf("escalation_policies/%s", id)
 
 	policy := new(escalationPolicyWrapper)
-	resp, err := s.client.Get(uri, policy)
+	resp, err := s.client.GetContext(ctx, uri, policy)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return policy.EscalationPolicy, resp, err
 }
+
+// EscalationPolicyValidationError reports an escalation policy that would be
+// rejected by PagerDuty.
+type EscalationPolicyValidationError struct {
+	// The JSON path of the invalid field, e.g. 'escalation_rules[0].targets'.
+	Field string
+
+	// Why the field is invalid.
+	Reason string
+}
+
+func (e *EscalationPolicyValidationError) Error() string {
+	return fmt.Sprintf("pagerduty: invalid escalation policy field %q: %s", e.Field, e.Reason)
+}
+
+// Validate checks the escalation policy and its rules for the problems the
+// API would reject it for. It returns an *EscalationPolicyValidationError
+// describing the first problem found.
+func (p *EscalationPolicy) Validate() error {
+	if p.Name == nil || *p.Name == "" {
+		return &EscalationPolicyValidationError{"name", "is required"}
+	}
+
+	if p.NumLoops != nil && (*p.NumLoops < 0 || *p.NumLoops > MaxEscalationPolicyLoops) {
+		return &EscalationPolicyValidationError{"num_loops", fmt.Sprintf("must be between 0 and %d", MaxEscalationPolicyLoops)}
+	}
+
+	if len(p.EscalationRules) == 0 {
+		return &EscalationPolicyValidationError{"escalation_rules", "must not be empty"}
+	}
+
+	for i := range p.EscalationRules {
+		if err := p.EscalationRules[i].Validate(); err != nil {
+			err := err.(*EscalationPolicyValidationError)
+			err.Field = fmt.Sprintf("escalation_rules[%d].%s", i, err.Field)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the escalation rule and its targets for the problems the
+// API would reject it for. It returns an *EscalationPolicyValidationError
+// describing the first problem found.
+func (r *EscalationRule) Validate() error {
+	if r.EscalationDelayInMinutes == nil {
+		return &EscalationPolicyValidationError{"escalation_delay_in_minutes", "is required"}
+	}
+	if *r.EscalationDelayInMinutes <= 0 {
+		return &EscalationPolicyValidationError{"escalation_delay_in_minutes", "must be positive"}
+	}
+
+	if len(r.Targets) == 0 {
+		return &EscalationPolicyValidationError{"targets", "must not be empty"}
+	}
+
+	for i, t := range r.Targets {
+		field := fmt.Sprintf("targets[%d]", i)
+
+		if t.ID == nil || *t.ID == "" {
+			return &EscalationPolicyValidationError{field + ".id", "is required"}
+		}
+
+		if t.Type == nil {
+			return &EscalationPolicyValidationError{field + ".type", "is required"}
+		}
+		switch *t.Type {
+		case TargetTypeUser, TargetTypeSchedule, ObjectTypeUserReference, ObjectTypeScheduleReference:
+		default:
+			return &EscalationPolicyValidationError{field + ".type", fmt.Sprintf("unknown target type %q", *t.Type)}
+		}
+	}
+
+	return nil
+}
+
+// body returns the request body creating or updating policy. The REST API v2
+// receives the targets as references.
+func (s *EscalationPoliciesService) body(policy *EscalationPolicy) interface{} {
+	if !s.client.isV2() {
+		return policy
+	}
+
+	p := *policy
+	p.EscalationRules = make([]EscalationRule, len(policy.EscalationRules))
+	for i, rule := range policy.EscalationRules {
+		targets := make([]Target, len(rule.Targets))
+		for j, t := range rule.Targets {
+			targets[j] = t
+			switch *t.Type {
+			case TargetTypeUser:
+				targets[j].Type = String(ObjectTypeUserReference)
+			case TargetTypeSchedule:
+				targets[j].Type = String(ObjectTypeScheduleReference)
+			}
+		}
+
+		rule.Targets = targets
+		p.EscalationRules[i] = rule
+	}
+
+	return &escalationPolicyWrapper{EscalationPolicy: &p}
+}
+
+// Create an escalation policy. The policy is validated before being sent.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/escalation_policies/create
+func (s *EscalationPoliciesService) Create(policy *EscalationPolicy) (*EscalationPolicy, *Response, error) {
+	return s.CreateContext(context.Background(), policy)
+}
+
+// CreateContext is the context aware variant of Create.
+func (s *EscalationPoliciesService) CreateContext(ctx context.Context, policy *EscalationPolicy) (*EscalationPolicy, *Response, error) {
+	if policy == nil {
+		return nil, nil, fmt.Errorf("pagerduty: escalation policy cannot be nil")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	uri := "escalation_policies"
+
+	p := new(escalationPolicyWrapper)
+	resp, err := s.client.PostContext(ctx, uri, s.body(policy), p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p.EscalationPolicy, resp, err
+}
+
+// Update an existing escalation policy. The policy is validated before being
+// sent, so it must be complete.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/escalation_policies/update
+func (s *EscalationPoliciesService) Update(policy *EscalationPolicy) (*EscalationPolicy, *Response, error) {
+	return s.UpdateContext(context.Background(), policy)
+}
+
+// UpdateContext is the context aware variant of Update.
+func (s *EscalationPoliciesService) UpdateContext(ctx context.Context, policy *EscalationPolicy) (*EscalationPolicy, *Response, error) {
+	if policy == nil || policy.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: escalation policy id cannot be nil")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	uri := fmt.Sprintf("escalation_policies/%s", *policy.ID)
+
+	p := new(escalationPolicyWrapper)
+	resp, err := s.client.PutContext(ctx, uri, s.body(policy), p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p.EscalationPolicy, resp, err
+}
+
+// Delete an escalation policy. The API refuses to delete a policy still used
+// by a service.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/escalation_policies/delete
+func (s *EscalationPoliciesService) Delete(id string) (*Response, error) {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is the context aware variant of Delete.
+func (s *EscalationPoliciesService) DeleteContext(ctx context.Context, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("escalation_policies/%s", id))
+}
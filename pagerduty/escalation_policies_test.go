@@ -117,4 +117,209 @@ var _ = Describe("EscalationPolicies", func() {
 			})
 		})
 	})
+
+	Describe("Create", func() {
+		var (
+			policy *EscalationPolicy
+			resp   *Response
+			err    error
+		)
+
+		newPolicy := func() *EscalationPolicy {
+			return &EscalationPolicy{
+				Name: String("name"),
+				EscalationRules: []EscalationRule{{
+					EscalationDelayInMinutes: Int(30),
+					Targets: []Target{
+						{ID: String("PT23IWX"), Type: String(TargetTypeUser)},
+					},
+				}},
+			}
+		}
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/escalation_policies", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"name": "name",
+						"escalation_rules": [{
+							"escalation_delay_in_minutes": 30,
+							"targets": [{ "id": "PT23IWX", "type": "user" }]
+						}]
+					}`),
+					ghttp.RespondWith(http.StatusCreated, escalationPolicyGetJSON),
+				))
+
+				policy, resp, err = env.Client.EscalationPolicies.Create(newPolicy())
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the created escalation policy", func() {
+				Expect(policy).To(Equal(&expectedEscalationPolicy))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/escalation_policies", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"escalation_policy": {
+							"name": "name",
+							"escalation_rules": [{
+								"escalation_delay_in_minutes": 30,
+								"targets": [{ "id": "PT23IWX", "type": "user_reference" }]
+							}]
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, escalationPolicyGetJSON),
+				))
+
+				policy, resp, err = env.Client.EscalationPolicies.Create(newPolicy())
+			})
+
+			It("should send the targets as references", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policy).To(Equal(&expectedEscalationPolicy))
+			})
+		})
+
+		Context("with an invalid escalation policy", func() {
+			BeforeEach(func() {
+				invalid := newPolicy()
+				invalid.EscalationRules[0].Targets = nil
+
+				policy, resp, err = env.Client.EscalationPolicies.Create(invalid)
+			})
+
+			It("should return a validation error without making a request", func() {
+				Expect(err).To(BeAssignableToTypeOf(new(EscalationPolicyValidationError)))
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		It("should update the escalation policy", func() {
+			env.Server.RouteToHandler(PUT, "/escalation_policies/id", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusOK, escalationPolicyGetJSON),
+			))
+
+			policy, _, err := env.Client.EscalationPolicies.Update(&EscalationPolicy{
+				ID:   String("id"),
+				Name: String("name"),
+				EscalationRules: []EscalationRule{{
+					EscalationDelayInMinutes: Int(30),
+					Targets:                  []Target{{ID: String("PI7DH85"), Type: String(TargetTypeSchedule)}},
+				}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(&expectedEscalationPolicy))
+		})
+
+		It("should return an error without an id", func() {
+			_, _, err := env.Client.EscalationPolicies.Update(&EscalationPolicy{})
+			Expect(err).To(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete the escalation policy", func() {
+			env.Server.RouteToHandler(DELETE, "/escalation_policies/id", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.EscalationPolicies.Delete("id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("Validate", func() {
+		var policy *EscalationPolicy
+
+		BeforeEach(func() {
+			policy = &EscalationPolicy{
+				Name:     String("name"),
+				NumLoops: Int(2),
+				EscalationRules: []EscalationRule{
+					{
+						EscalationDelayInMinutes: Int(30),
+						Targets:                  []Target{{ID: String("PT23IWX"), Type: String(TargetTypeUser)}},
+					},
+					{
+						EscalationDelayInMinutes: Int(30),
+						Targets:                  []Target{{ID: String("PI7DH85"), Type: String(ObjectTypeScheduleReference)}},
+					},
+				},
+			}
+		})
+
+		expectInvalid := func(field string) {
+			err := policy.Validate()
+			Expect(err).To(BeAssignableToTypeOf(new(EscalationPolicyValidationError)))
+			Expect(err.(*EscalationPolicyValidationError).Field).To(Equal(field))
+		}
+
+		It("should accept a valid escalation policy", func() {
+			Expect(policy.Validate()).To(Succeed())
+		})
+
+		It("should reject a policy without a name", func() {
+			policy.Name = nil
+			expectInvalid("name")
+		})
+
+		It("should reject a policy without rules", func() {
+			policy.EscalationRules = nil
+			expectInvalid("escalation_rules")
+		})
+
+		It("should reject loop counts out of range", func() {
+			policy.NumLoops = Int(MaxEscalationPolicyLoops + 1)
+			expectInvalid("num_loops")
+
+			policy.NumLoops = Int(-1)
+			expectInvalid("num_loops")
+		})
+
+		It("should reject rules without targets", func() {
+			policy.EscalationRules[1].Targets = []Target{}
+			expectInvalid("escalation_rules[1].targets")
+		})
+
+		It("should reject non-positive escalation delays", func() {
+			policy.EscalationRules[0].EscalationDelayInMinutes = Int(0)
+			expectInvalid("escalation_rules[0].escalation_delay_in_minutes")
+		})
+
+		It("should reject unknown target types", func() {
+			policy.EscalationRules[1].Targets[0].Type = String("team")
+			expectInvalid("escalation_rules[1].targets[0].type")
+		})
+
+		It("should reject targets without an id", func() {
+			policy.EscalationRules[0].Targets[0].ID = nil
+			expectInvalid("escalation_rules[0].targets[0].id")
+		})
+	})
 })
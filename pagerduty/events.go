@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -23,7 +24,13 @@ const (
 // NewEventRequest creates an API request for the PagerDuty Events API. It has
 // the same requirements and behaviors as Client.NewRequest.
 func (c *Client) NewEventRequest(method, path string, body interface{}) (*http.Request, error) {
-	return newRequest(c.EventsURL, method, path, body)
+	return c.NewEventRequestContext(context.Background(), method, path, body)
+}
+
+// NewEventRequestContext creates an API request for the PagerDuty Events API
+// bound to ctx.
+func (c *Client) NewEventRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	return newRequest(ctx, c.EventsURL, method, path, body)
 }
 
 type EventResponse struct {
@@ -38,10 +45,19 @@ type EventResponse struct {
 // DoEventRequest sends a request to the PagerDuty Events API and returns the
 // response.
 func (c *Client) DoEventRequest(req *http.Request) (*EventResponse, error) {
-	resp, err := c.client.Do(req)
+	return c.DoEventRequestContext(req.Context(), req)
+}
+
+// DoEventRequestContext sends a request bound to ctx to the PagerDuty Events
+// API and returns the response. If ctx is canceled or its deadline is exceeded
+// before the response is received, ctx.Err() is returned. Failed requests are
+// retried according to the client's RetryPolicy.
+func (c *Client) DoEventRequestContext(ctx context.Context, req *http.Request) (*EventResponse, error) {
+	resp, err := c.send(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	eventResp := new(EventResponse)
 	err = json.NewDecoder(resp.Body).Decode(eventResp)
@@ -102,37 +118,52 @@ type ImageContext struct {
 }
 
 // helper function to post an event and unmarshal the event response.
-func (s *EventsService) postEvent(event *Event, eventType string) (*EventResponse, error) {
+func (s *EventsService) postEvent(ctx context.Context, event *Event, eventType string) (*EventResponse, error) {
 	if event == nil {
 		return nil, fmt.Errorf("pagerduty: event cannot be nil")
 	}
 
 	event.Type = String(eventType)
-	req, err := s.client.NewEventRequest(POST, eventsAPIPath, event)
+	req, err := s.client.NewEventRequestContext(ctx, POST, eventsAPIPath, event)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.DoEventRequest(req)
+	return s.client.DoEventRequestContext(ctx, req)
 }
 
 // Acknowledge an event (incident).
 //
 // https://developer.pagerduty.com/documentation/integration/events/acknowledge
 func (s *EventsService) Acknowledge(event *Event) (*EventResponse, error) {
-	return s.postEvent(event, EventTypeAcknowledge)
+	return s.AcknowledgeContext(context.Background(), event)
+}
+
+// AcknowledgeContext is the context aware variant of Acknowledge.
+func (s *EventsService) AcknowledgeContext(ctx context.Context, event *Event) (*EventResponse, error) {
+	return s.postEvent(ctx, event, EventTypeAcknowledge)
 }
 
 // Resolve an event (incident).
 //
 // https://developer.pagerduty.com/documentation/integration/events/resolve
 func (s *EventsService) Resolve(event *Event) (*EventResponse, error) {
-	return s.postEvent(event, EventTypeResolve)
+	return s.ResolveContext(context.Background(), event)
+}
+
+// ResolveContext is the context aware variant of Resolve.
+func (s *EventsService) ResolveContext(ctx context.Context, event *Event) (*EventResponse, error) {
+	return s.postEvent(ctx, event, EventTypeResolve)
 }
 
 // Trigger an event (incident).
 //
 // https://developer.pagerduty.com/documentation/integration/events/trigger
 func (s *EventsService) Trigger(event *Event) (*EventResponse, error) {
-	return s.postEvent(event, EventTypeTrigger)
+	return s.TriggerContext(context.Background(), event)
+}
+
+// TriggerContext is the context aware variant of Trigger.
+func (s *EventsService) TriggerContext(ctx context.Context, event *Event) (*EventResponse, error) {
+	return s.postEvent(ctx, event, EventTypeTrigger)
 }
@@ -7,8 +7,10 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 )
 
 const (
@@ -275,4 +277,46 @@ var _ = Describe("Events", func() {
 			})
 		})
 	})
+
+	Describe("TriggerContext", func() {
+		Context("with a canceled context", func() {
+			BeforeEach(func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				resp, err = env.Client.Events.TriggerContext(ctx, new(Event))
+			})
+
+			It("should not have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+
+			It("should return the context's error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+
+		Context("when the deadline is exceeded before the server responds", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, eventsAPIURL, ghttp.CombineHandlers(
+					verifyContentHeaderHandler,
+					func(w http.ResponseWriter, r *http.Request) {
+						time.Sleep(50 * time.Millisecond)
+					},
+					ghttp.RespondWith(http.StatusOK, eventSuccessResponseJSON),
+				))
+
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+
+				resp, err = env.Client.Events.TriggerContext(ctx, new(Event))
+			})
+
+			It("should return the context's error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(context.DeadlineExceeded))
+			})
+		})
+	})
 })
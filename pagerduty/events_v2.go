@@ -0,0 +1,253 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	eventsV2APIPath = "v2/enqueue"
+
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+
+	// maximum length of an Events API v2 payload summary
+	maxEventV2SummaryLength = 1024
+)
+
+// EventV2 represents an event sent to the PagerDuty Events API v2.
+//
+// https://developer.pagerduty.com/docs/events-api-v2/trigger-events/
+type EventV2 struct {
+	// The integration key of the service the event is sent to.
+	RoutingKey *string `json:"routing_key,omitempty"`
+
+	// The type of the event. It is set by the EventsService methods.
+	Action *string `json:"event_action,omitempty"`
+
+	// Deduplication key for correlating triggers and resolves. It is required
+	// to acknowledge or resolve an alert.
+	DedupKey *string `json:"dedup_key,omitempty"`
+
+	// The name of the monitoring client triggering the event.
+	Client *string `json:"client,omitempty"`
+
+	// The URL of the monitoring client triggering the event.
+	ClientURL *string `json:"client_url,omitempty"`
+
+	// Information about the event. It is required to trigger an alert.
+	Payload *EventV2Payload `json:"payload,omitempty"`
+
+	Links  []EventV2Link  `json:"links,omitempty"`
+	Images []EventV2Image `json:"images,omitempty"`
+}
+
+// EventV2Payload holds the details of an Events API v2 event.
+type EventV2Payload struct {
+	// A brief text summary of the event. Required.
+	Summary *string `json:"summary,omitempty"`
+
+	// The unique location of the affected system, preferably a hostname or
+	// FQDN. Required.
+	Source *string `json:"source,omitempty"`
+
+	// The perceived severity of the status the event is describing. Possible
+	// values are 'critical', 'error', 'warning' and 'info'. Required.
+	Severity *string `json:"severity,omitempty"`
+
+	// The time at which the emitting tool detected or generated the event.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+
+	// Component of the source machine that is responsible for the event.
+	Component *string `json:"component,omitempty"`
+
+	// Logical grouping of components of a service.
+	Group *string `json:"group,omitempty"`
+
+	// The class/type of the event.
+	Class *string `json:"class,omitempty"`
+
+	// Additional details about the event and affected system.
+	CustomDetails interface{} `json:"custom_details,omitempty"`
+}
+
+// EventV2Link is a link attached to an Events API v2 event.
+type EventV2Link struct {
+	// URL of the link to be attached.
+	Href string `json:"href"`
+
+	// Plain text that describes the purpose of the link.
+	Text string `json:"text,omitempty"`
+}
+
+// EventV2Image is an image attached to an Events API v2 event.
+type EventV2Image struct {
+	// The source of the image being attached. This must be served via HTTPS.
+	Source string `json:"src"`
+
+	// Optional URL, makes the image a clickable link.
+	Href string `json:"href,omitempty"`
+
+	// Optional alternative text for the image.
+	Alt string `json:"alt,omitempty"`
+}
+
+// EventV2Response is the response of the PagerDuty Events API v2.
+type EventV2Response struct {
+	Response *http.Response
+
+	Status   string   `json:"status,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	DedupKey string   `json:"dedup_key,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// EventValidationError reports an Events API v2 event that would be rejected
+// by PagerDuty.
+type EventValidationError struct {
+	// The JSON name of the invalid field.
+	Field string
+
+	// Why the field is invalid.
+	Reason string
+}
+
+func (e *EventValidationError) Error() string {
+	return fmt.Sprintf("pagerduty: invalid event field %q: %s", e.Field, e.Reason)
+}
+
+// Validate checks that the event has every field required by the given
+// action ('trigger', 'acknowledge' or 'resolve'). It returns an
+// *EventValidationError describing the first problem found.
+func (e *EventV2) Validate(action string) error {
+	if e.RoutingKey == nil || *e.RoutingKey == "" {
+		return &EventValidationError{"routing_key", "is required"}
+	}
+
+	switch action {
+	case EventTypeTrigger:
+		return e.Payload.validate()
+	case EventTypeAcknowledge, EventTypeResolve:
+		if e.DedupKey == nil || *e.DedupKey == "" {
+			return &EventValidationError{"dedup_key", "is required to " + action + " an alert"}
+		}
+	default:
+		return &EventValidationError{"event_action", fmt.Sprintf("unknown action %q", action)}
+	}
+
+	return nil
+}
+
+func (p *EventV2Payload) validate() error {
+	if p == nil {
+		return &EventValidationError{"payload", "is required to trigger an alert"}
+	}
+
+	if p.Summary == nil || *p.Summary == "" {
+		return &EventValidationError{"payload.summary", "is required"}
+	}
+	if len(*p.Summary) > maxEventV2SummaryLength {
+		return &EventValidationError{"payload.summary", fmt.Sprintf("must not exceed %d characters", maxEventV2SummaryLength)}
+	}
+
+	if p.Source == nil || *p.Source == "" {
+		return &EventValidationError{"payload.source", "is required"}
+	}
+
+	if p.Severity == nil {
+		return &EventValidationError{"payload.severity", "is required"}
+	}
+	switch *p.Severity {
+	case SeverityCritical, SeverityError, SeverityWarning, SeverityInfo:
+	default:
+		return &EventValidationError{"payload.severity", fmt.Sprintf("unknown severity %q", *p.Severity)}
+	}
+
+	return nil
+}
+
+// DoEventV2RequestContext sends a request bound to ctx to the PagerDuty Events
+// API v2 and returns the response. Responses with a status code outside the
+// 200 range, such as rejected events, are returned along with an
+// *ErrorResponse carrying the errors reported by PagerDuty.
+func (c *Client) DoEventV2RequestContext(ctx context.Context, req *http.Request) (*EventV2Response, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	eventResp := new(EventV2Response)
+	err = json.NewDecoder(resp.Body).Decode(eventResp)
+	eventResp.Response = resp
+
+	if code := resp.StatusCode; code < 200 || code > 299 {
+		// the body of server errors may not be JSON
+		return eventResp, &ErrorResponse{Response: resp, Message: eventResp.Message, Errors: eventResp.Errors}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return eventResp, nil
+}
+
+// helper function to validate and post an Events API v2 event.
+func (s *EventsService) postEventV2(ctx context.Context, event *EventV2, action string) (*EventV2Response, error) {
+	if event == nil {
+		return nil, fmt.Errorf("pagerduty: event cannot be nil")
+	}
+
+	if err := event.Validate(action); err != nil {
+		return nil, err
+	}
+
+	event.Action = String(action)
+	req, err := s.client.NewEventRequestContext(ctx, POST, eventsV2APIPath, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DoEventV2RequestContext(ctx, req)
+}
+
+// AcknowledgeV2 acknowledges an alert using the Events API v2.
+//
+// https://developer.pagerduty.com/docs/events-api-v2/acknowledge-events/
+func (s *EventsService) AcknowledgeV2(event *EventV2) (*EventV2Response, error) {
+	return s.AcknowledgeV2Context(context.Background(), event)
+}
+
+// AcknowledgeV2Context is the context aware variant of AcknowledgeV2.
+func (s *EventsService) AcknowledgeV2Context(ctx context.Context, event *EventV2) (*EventV2Response, error) {
+	return s.postEventV2(ctx, event, EventTypeAcknowledge)
+}
+
+// ResolveV2 resolves an alert using the Events API v2.
+//
+// https://developer.pagerduty.com/docs/events-api-v2/resolve-events/
+func (s *EventsService) ResolveV2(event *EventV2) (*EventV2Response, error) {
+	return s.ResolveV2Context(context.Background(), event)
+}
+
+// ResolveV2Context is the context aware variant of ResolveV2.
+func (s *EventsService) ResolveV2Context(ctx context.Context, event *EventV2) (*EventV2Response, error) {
+	return s.postEventV2(ctx, event, EventTypeResolve)
+}
+
+// TriggerV2 triggers an alert using the Events API v2.
+//
+// https://developer.pagerduty.com/docs/events-api-v2/trigger-events/
+func (s *EventsService) TriggerV2(event *EventV2) (*EventV2Response, error) {
+	return s.TriggerV2Context(context.Background(), event)
+}
+
+// TriggerV2Context is the context aware variant of TriggerV2.
+func (s *EventsService) TriggerV2Context(ctx context.Context, event *EventV2) (*EventV2Response, error) {
+	return s.postEventV2(ctx, event, EventTypeTrigger)
+}
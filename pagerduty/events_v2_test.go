@@ -0,0 +1,257 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	eventsV2APIURL = "/v2/enqueue"
+
+	eventV2SuccessResponseJSON = `{
+		"status": "success",
+		"message": "Event processed",
+		"dedup_key": "dedup_key"
+	}`
+
+	eventV2ErrorResponseJSON = `{
+		"status": "invalid event",
+		"message": "Event object is invalid",
+		"errors": [
+			"'payload.summary' is missing or blank"
+		]
+	}`
+)
+
+func newTriggerEventV2() *EventV2 {
+	return &EventV2{
+		RoutingKey: String("routing_key"),
+		Payload: &EventV2Payload{
+			Summary:   String("summary"),
+			Source:    String("source"),
+			Severity:  String(SeverityCritical),
+			Timestamp: Time(time.Date(2015, time.October, 29, 0, 0, 0, 0, time.UTC)),
+			Component: String("component"),
+			Group:     String("group"),
+			Class:     String("class"),
+			CustomDetails: map[string]string{
+				"key": "value",
+			},
+		},
+		Links:  []EventV2Link{{Href: "https://example.com", Text: "text"}},
+		Images: []EventV2Image{{Source: "https://example.com/image.png", Alt: "alt"}},
+	}
+}
+
+var _ = Describe("Events v2", func() {
+	var (
+		env  *TestEnvironment
+		resp *EventV2Response
+		err  error
+	)
+
+	BeforeEach(func() { env = NewTestEnvironment() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("TriggerV2", func() {
+		Context("with a valid event", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, eventsV2APIURL, ghttp.CombineHandlers(
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"routing_key": "routing_key",
+						"event_action": "trigger",
+						"payload": {
+							"summary": "summary",
+							"source": "source",
+							"severity": "critical",
+							"timestamp": "2015-10-29T00:00:00Z",
+							"component": "component",
+							"group": "group",
+							"class": "class",
+							"custom_details": { "key": "value" }
+						},
+						"links": [{ "href": "https://example.com", "text": "text" }],
+						"images": [{ "src": "https://example.com/image.png", "alt": "alt" }]
+					}`),
+					ghttp.RespondWith(http.StatusAccepted, eventV2SuccessResponseJSON),
+				))
+
+				resp, err = env.Client.Events.TriggerV2(newTriggerEventV2())
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the expected event response", func() {
+				Expect(resp).To(Equal(&EventV2Response{
+					Response: resp.Response,
+					Status:   "success",
+					Message:  "Event processed",
+					DedupKey: "dedup_key",
+				}))
+			})
+		})
+
+		Context("when the event is rejected by the server", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, eventsV2APIURL,
+					ghttp.RespondWith(http.StatusBadRequest, eventV2ErrorResponseJSON),
+				)
+
+				resp, err = env.Client.Events.TriggerV2(newTriggerEventV2())
+			})
+
+			It("should return an error carrying the errors reported by the server", func() {
+				Expect(err).To(BeAssignableToTypeOf(new(ErrorResponse)))
+				Expect(err.(*ErrorResponse).Response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(err.(*ErrorResponse).Message).To(Equal("Event object is invalid"))
+				Expect(err.(*ErrorResponse).Errors).To(Equal([]string{"'payload.summary' is missing or blank"}))
+			})
+
+			It("should return the response", func() {
+				Expect(resp.Status).To(Equal(EventStatusError))
+				Expect(resp.Errors).To(HaveLen(1))
+			})
+		})
+
+		Context("with a nil event", func() {
+			BeforeEach(func() {
+				resp, err = env.Client.Events.TriggerV2(nil)
+			})
+
+			It("should return an error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("with an invalid event", func() {
+			var event *EventV2
+
+			BeforeEach(func() { event = newTriggerEventV2() })
+
+			JustBeforeEach(func() {
+				resp, err = env.Client.Events.TriggerV2(event)
+			})
+
+			expectInvalidField := func(field string) {
+				It("should not have made a request", func() {
+					Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+				})
+
+				It("should return a validation error for the field", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(BeAssignableToTypeOf(new(EventValidationError)))
+					Expect(err.(*EventValidationError).Field).To(Equal(field))
+				})
+			}
+
+			Context("without a routing key", func() {
+				BeforeEach(func() { event.RoutingKey = nil })
+				expectInvalidField("routing_key")
+			})
+
+			Context("without a payload", func() {
+				BeforeEach(func() { event.Payload = nil })
+				expectInvalidField("payload")
+			})
+
+			Context("without a summary", func() {
+				BeforeEach(func() { event.Payload.Summary = String("") })
+				expectInvalidField("payload.summary")
+			})
+
+			Context("with a summary that is too long", func() {
+				BeforeEach(func() { event.Payload.Summary = String(strings.Repeat("a", 1025)) })
+				expectInvalidField("payload.summary")
+			})
+
+			Context("without a source", func() {
+				BeforeEach(func() { event.Payload.Source = nil })
+				expectInvalidField("payload.source")
+			})
+
+			Context("with an unknown severity", func() {
+				BeforeEach(func() { event.Payload.Severity = String("fatal") })
+				expectInvalidField("payload.severity")
+			})
+		})
+	})
+
+	Describe("AcknowledgeV2", func() {
+		Context("with a dedup key", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, eventsV2APIURL, ghttp.CombineHandlers(
+					ghttp.VerifyJSON(`{
+						"routing_key": "routing_key",
+						"event_action": "acknowledge",
+						"dedup_key": "dedup_key"
+					}`),
+					ghttp.RespondWith(http.StatusAccepted, eventV2SuccessResponseJSON),
+				))
+
+				resp, err = env.Client.Events.AcknowledgeV2(&EventV2{
+					RoutingKey: String("routing_key"),
+					DedupKey:   String("dedup_key"),
+				})
+			})
+
+			It("should not return an error", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.DedupKey).To(Equal("dedup_key"))
+			})
+		})
+
+		Context("without a dedup key", func() {
+			BeforeEach(func() {
+				resp, err = env.Client.Events.AcknowledgeV2(&EventV2{
+					RoutingKey: String("routing_key"),
+				})
+			})
+
+			It("should return a validation error", func() {
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+				Expect(err).To(BeAssignableToTypeOf(new(EventValidationError)))
+			})
+		})
+	})
+
+	Describe("ResolveV2", func() {
+		Context("with a dedup key", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, eventsV2APIURL, ghttp.CombineHandlers(
+					ghttp.VerifyJSON(`{
+						"routing_key": "routing_key",
+						"event_action": "resolve",
+						"dedup_key": "dedup_key"
+					}`),
+					ghttp.RespondWith(http.StatusAccepted, eventV2SuccessResponseJSON),
+				))
+
+				resp, err = env.Client.Events.ResolveV2(&EventV2{
+					RoutingKey: String("routing_key"),
+					DedupKey:   String("dedup_key"),
+				})
+			})
+
+			It("should not return an error", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,285 @@
+package pagerduty
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	icsProductID   = "-//hudl//go-pagerduty//EN"
+	icsDateTime    = "20060102T150405"
+	icsMaxLineSize = 75
+)
+
+// ICSCalendar is an iCalendar (RFC 5545) feed of on-call shifts, built from
+// the rendered entries of one or more schedules.
+//
+// Every shift is an event whose UID is derived from its schedule, user and
+// start, so that regenerating a feed keeps the UIDs of unchanged shifts.
+// Entries truncated to a date range change their start, so schedules should
+// be rendered with overflow to keep the shifts whole.
+type ICSCalendar struct {
+	// The name of the calendar.
+	Name string
+
+	// Time zone the events are written in. A nil TimeZone writes UTC times.
+	TimeZone *TimeZone
+
+	// Time written as the DTSTAMP of the events. Defaults to the time the
+	// calendar is written.
+	Timestamp time.Time
+
+	// When set, only the shifts of this user are added.
+	user   *User
+	events []icsEvent
+}
+
+type icsEvent struct {
+	uid         string
+	start, end  time.Time
+	summary     string
+	description string
+}
+
+// NewScheduleICS returns a calendar of the shifts of every user in entries,
+// named after the schedule and written in its time zone.
+func NewScheduleICS(schedule *Schedule, entries []RenderedScheduleEntry) *ICSCalendar {
+	c := &ICSCalendar{TimeZone: schedule.TimeZone}
+	if schedule.Name != nil {
+		c.Name = *schedule.Name
+	}
+
+	c.Add(schedule, entries)
+	return c
+}
+
+// NewUserICS returns an empty calendar of the shifts of user, named after
+// the user and written in their time zone. Shifts are added with Add.
+func NewUserICS(user *User) *ICSCalendar {
+	c := &ICSCalendar{TimeZone: user.TimeZone, user: user}
+	if user.Name != nil {
+		c.Name = *user.Name
+	}
+
+	return c
+}
+
+// Add adds the shifts in entries, rendered from schedule, to the calendar.
+// Calendars created with NewUserICS only add the shifts of their user.
+func (c *ICSCalendar) Add(schedule *Schedule, entries []RenderedScheduleEntry) {
+	var scheduleID, scheduleName string
+	if schedule.ID != nil {
+		scheduleID = *schedule.ID
+	}
+	if schedule.Name != nil {
+		scheduleName = *schedule.Name
+	}
+
+	for _, e := range entries {
+		if e.Start == nil || e.End == nil || e.User == nil {
+			continue
+		}
+		if c.user != nil && userKey(e.User) != userKey(c.user) {
+			continue
+		}
+
+		userName := userKey(e.User)
+		if e.User.Name != nil {
+			userName = *e.User.Name
+		}
+
+		event := icsEvent{
+			uid:   icsUID(scheduleID, userKey(e.User), *e.Start),
+			start: *e.Start,
+			end:   *e.End,
+		}
+		if c.user != nil {
+			event.summary = "On call: " + scheduleName
+		} else {
+			event.summary = userName + " on call"
+		}
+		if schedule.HTMLURL != nil {
+			event.description = *schedule.HTMLURL
+		}
+
+		c.events = append(c.events, event)
+	}
+}
+
+// icsUID returns the stable UID of a shift.
+func icsUID(scheduleID, user string, start time.Time) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%d", scheduleID, user, start.Unix())))
+	return hex.EncodeToString(sum[:]) + "@go-pagerduty"
+}
+
+// WriteTo writes the calendar to w, its events sorted by start.
+func (c *ICSCalendar) WriteTo(w io.Writer) (int64, error) {
+	loc := time.UTC
+	if c.TimeZone != nil && c.TimeZone.Location != nil {
+		loc = c.TimeZone.Location
+	}
+
+	stamp := c.Timestamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	events := append([]icsEvent(nil), c.events...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].start.Before(events[j].start) })
+
+	iw := &icsWriter{w: bufio.NewWriter(w)}
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:" + icsProductID)
+	iw.line("CALSCALE:GREGORIAN")
+	iw.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		iw.line("X-WR-CALNAME:" + icsEscape(c.Name))
+	}
+	if loc != time.UTC {
+		iw.line("X-WR-TIMEZONE:" + loc.String())
+		if len(events) > 0 {
+			iw.timeZone(loc, events[0].start, events[len(events)-1].end)
+		}
+	}
+
+	for _, e := range events {
+		iw.line("BEGIN:VEVENT")
+		iw.line("UID:" + e.uid)
+		iw.line("DTSTAMP:" + stamp.UTC().Format(icsDateTime) + "Z")
+		iw.line("DTSTART" + icsTime(e.start, loc))
+		iw.line("DTEND" + icsTime(e.end, loc))
+		iw.line("SUMMARY:" + icsEscape(e.summary))
+		if e.description != "" {
+			iw.line("DESCRIPTION:" + icsEscape(e.description))
+		}
+		iw.line("TRANSP:OPAQUE")
+		iw.line("END:VEVENT")
+	}
+
+	iw.line("END:VCALENDAR")
+
+	if iw.err == nil {
+		iw.err = iw.w.Flush()
+	}
+	return iw.n, iw.err
+}
+
+// icsTime returns the value of a DTSTART or DTEND property for t, including
+// the ':' separating it from the property name.
+func icsTime(t time.Time, loc *time.Location) string {
+	if loc == time.UTC {
+		return ":" + t.UTC().Format(icsDateTime) + "Z"
+	}
+
+	return ";TZID=" + loc.String() + ":" + t.In(loc).Format(icsDateTime)
+}
+
+// icsEscape escapes a TEXT value.
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// icsWriter writes CRLF terminated content lines, folded at 75 octets.
+type icsWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *icsWriter) line(s string) {
+	// continuation lines start with a space counting toward their size
+	for size := icsMaxLineSize; len(s) > size; size = icsMaxLineSize - 1 {
+		// fold without splitting a UTF-8 sequence
+		i := size
+		for i > 0 && s[i]&0xC0 == 0x80 {
+			i--
+		}
+
+		w.write(s[:i] + "\r\n ")
+		s = s[i:]
+	}
+
+	w.write(s + "\r\n")
+}
+
+func (w *icsWriter) write(s string) {
+	if w.err != nil {
+		return
+	}
+
+	n, err := w.w.WriteString(s)
+	w.n += int64(n)
+	w.err = err
+}
+
+// timeZone writes the VTIMEZONE component of loc, with one observance per
+// offset change between from and to, and the observance in effect at from.
+func (w *icsWriter) timeZone(loc *time.Location, from, to time.Time) {
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + loc.String())
+
+	prevName, prevOffset := from.In(loc).Zone()
+	w.observance(from.In(loc), prevName, prevOffset, prevOffset)
+
+	for t := from; t.Before(to); {
+		next := t.AddDate(0, 0, 1)
+		name, offset := next.In(loc).Zone()
+		if offset != prevOffset {
+			change := zoneChange(loc, t, next)
+			w.observance(change.In(loc), name, prevOffset, offset)
+			prevOffset = offset
+		}
+		t = next
+	}
+
+	w.line("END:VTIMEZONE")
+}
+
+// observance writes a STANDARD or DAYLIGHT observance starting at start.
+func (w *icsWriter) observance(start time.Time, name string, from, to int) {
+	kind := "STANDARD"
+	if start.IsDST() {
+		kind = "DAYLIGHT"
+	}
+
+	w.line("BEGIN:" + kind)
+	// DTSTART is the local time before the change
+	w.line("DTSTART:" + start.In(time.FixedZone("", from)).Format(icsDateTime))
+	w.line("TZOFFSETFROM:" + icsOffset(from))
+	w.line("TZOFFSETTO:" + icsOffset(to))
+	w.line("TZNAME:" + name)
+	w.line("END:" + kind)
+}
+
+// zoneChange returns the first second in (from, to] at which the offset of
+// loc differs from its offset at from.
+func zoneChange(loc *time.Location, from, to time.Time) time.Time {
+	_, offset := from.In(loc).Zone()
+	for to.Sub(from) > time.Second {
+		mid := from.Add(to.Sub(from) / 2).Truncate(time.Second)
+		if _, o := mid.In(loc).Zone(); o == offset {
+			from = mid
+		} else {
+			to = mid
+		}
+	}
+
+	return to
+}
+
+func icsOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+}
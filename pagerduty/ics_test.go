@@ -0,0 +1,147 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"bytes"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var _ = Describe("ICS", func() {
+	var (
+		alice = User{ID: String("PALICE1"), Name: String("Alice")}
+		bob   = User{ID: String("PBOB001"), Name: String("Bob")}
+
+		start = time.Date(2015, time.November, 9, 9, 0, 0, 0, time.UTC)
+		stamp = time.Date(2015, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+		schedule *Schedule
+		entries  []RenderedScheduleEntry
+	)
+
+	entry := func(u User, from, to time.Time) RenderedScheduleEntry {
+		return RenderedScheduleEntry{Start: Time(from), End: Time(to), User: &u}
+	}
+
+	write := func(c *ICSCalendar) string {
+		c.Timestamp = stamp
+
+		var buf bytes.Buffer
+		n, err := c.WriteTo(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(buf.Len()))
+
+		return buf.String()
+	}
+
+	uids := func(ics string) []string {
+		return regexp.MustCompile(`UID:(\S+)`).FindAllString(ics, -1)
+	}
+
+	BeforeEach(func() {
+		schedule = &Schedule{
+			ID:   String("PI7DH85"),
+			Name: String("Primary, on call"),
+		}
+		schedule.HTMLURL = String("https://acme.pagerduty.com/schedules/PI7DH85")
+		entries = []RenderedScheduleEntry{
+			entry(alice, start, start.Add(24*time.Hour)),
+			entry(bob, start.Add(24*time.Hour), start.Add(48*time.Hour)),
+		}
+	})
+
+	Describe("NewScheduleICS", func() {
+		var ics string
+
+		BeforeEach(func() {
+			ics = write(NewScheduleICS(schedule, entries))
+		})
+
+		It("should write a calendar named after the schedule", func() {
+			Expect(ics).To(HavePrefix("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+			Expect(ics).To(ContainSubstring("X-WR-CALNAME:Primary\\, on call\r\n"))
+			Expect(ics).To(HaveSuffix("END:VCALENDAR\r\n"))
+		})
+
+		It("should write one event per shift in UTC", func() {
+			Expect(strings.Count(ics, "BEGIN:VEVENT")).To(Equal(2))
+			Expect(ics).To(ContainSubstring("DTSTAMP:20151101T000000Z\r\n"))
+			Expect(ics).To(ContainSubstring("DTSTART:20151109T090000Z\r\nDTEND:20151110T090000Z\r\nSUMMARY:Alice on call\r\n"))
+			Expect(ics).To(ContainSubstring("DTSTART:20151110T090000Z\r\nDTEND:20151111T090000Z\r\nSUMMARY:Bob on call\r\n"))
+			Expect(ics).NotTo(ContainSubstring("VTIMEZONE"))
+		})
+
+		It("should terminate every line with CRLF", func() {
+			Expect(strings.Count(ics, "\n")).To(Equal(strings.Count(ics, "\r\n")))
+		})
+
+		It("should keep the UIDs stable across regenerations", func() {
+			again := write(NewScheduleICS(schedule, []RenderedScheduleEntry{entries[1], entries[0]}))
+
+			Expect(uids(ics)).To(HaveLen(2))
+			Expect(uids(again)).To(Equal(uids(ics)))
+		})
+	})
+
+	Describe("NewUserICS", func() {
+		It("should only add the shifts of the user", func() {
+			c := NewUserICS(&bob)
+			c.Add(schedule, entries)
+			ics := write(c)
+
+			Expect(ics).To(ContainSubstring("X-WR-CALNAME:Bob\r\n"))
+			Expect(strings.Count(ics, "BEGIN:VEVENT")).To(Equal(1))
+			Expect(ics).To(ContainSubstring("SUMMARY:On call: Primary\\, on call\r\n"))
+			Expect(ics).To(ContainSubstring("DTSTART:20151110T090000Z\r\n"))
+		})
+	})
+
+	Context("with a time zone observing DST", func() {
+		var ics string
+
+		BeforeEach(func() {
+			newYork, _ := time.LoadLocation("America/New_York")
+			schedule.TimeZone = &TimeZone{newYork}
+
+			from := time.Date(2015, time.October, 31, 9, 0, 0, 0, newYork)
+			entries = []RenderedScheduleEntry{
+				entry(alice, from, from.AddDate(0, 0, 1)),
+				entry(bob, from.AddDate(0, 0, 1), from.AddDate(0, 0, 2)),
+			}
+
+			ics = write(NewScheduleICS(schedule, entries))
+		})
+
+		It("should write the times in the time zone", func() {
+			Expect(ics).To(ContainSubstring("X-WR-TIMEZONE:America/New_York\r\n"))
+			Expect(ics).To(ContainSubstring("DTSTART;TZID=America/New_York:20151031T090000\r\n"))
+			Expect(ics).To(ContainSubstring("DTEND;TZID=America/New_York:20151101T090000\r\n"))
+			Expect(ics).To(ContainSubstring("DTSTART;TZID=America/New_York:20151101T090000\r\n"))
+		})
+
+		It("should describe the time zone and its offset changes", func() {
+			Expect(ics).To(ContainSubstring("BEGIN:VTIMEZONE\r\nTZID:America/New_York\r\n"))
+			Expect(ics).To(ContainSubstring("BEGIN:DAYLIGHT\r\nDTSTART:20151031T090000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0400\r\nTZNAME:EDT\r\n"))
+			Expect(ics).To(ContainSubstring("BEGIN:STANDARD\r\nDTSTART:20151101T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\n"))
+			Expect(strings.Index(ics, "END:VTIMEZONE")).To(BeNumerically("<", strings.Index(ics, "BEGIN:VEVENT")))
+		})
+	})
+
+	It("should fold long lines at 75 octets", func() {
+		schedule.HTMLURL = String("https://acme.pagerduty.com/schedules/PI7DH85?" + strings.Repeat("é", 60))
+		ics := write(NewScheduleICS(schedule, entries))
+
+		for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+			Expect(len(line)).To(BeNumerically("<=", 75))
+		}
+		Expect(ics).To(ContainSubstring("\r\n "))
+
+		unfolded := strings.Replace(ics, "\r\n ", "", -1)
+		Expect(unfolded).To(ContainSubstring("DESCRIPTION:" + *schedule.HTMLURL + "\r\n"))
+	})
+})
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -23,6 +24,7 @@ type IncidentsService struct {
 
 // Incident represents a PagerDuty incident.
 type Incident struct {
+	ID                 *string           `json:"id,omitempty"`
 	Number             *int              `json:"incident_number,omitempty"`
 	Status             *string           `json:"status,omitempty"`
 	Urgency            *string           `json:"urgency,omitempty"`
@@ -36,14 +38,24 @@ type Incident struct {
 	AssignedTo         []ObjectAt        `json:"assigned_to,omitempty"`
 	Acknowledgers      []ObjectAt        `json:"acknowledgers,omitempty"`
 	LastStatusChangeBy *User             `json:"last_status_change_by,omitempty"`
-	LastStatusChangeOn *time.Time        `json:"last_status_chage_on,omitempty"`
+	LastStatusChangeOn *time.Time        `json:"last_status_change_on,omitempty"`
 	TriggerSummary     *TriggerSummary   `json:"trigger_summary_data,omitempty"`
 	TriggerDetailsURL  *string           `json:"trigger_details_html_url,omitempty"`
 
+	// REST API v2 fields, which replace CreatedOn, AssignedTo, Acknowledgers
+	// and LastStatusChangeOn.
+	Title              *string           `json:"title,omitempty"`
+	CreatedAt          *time.Time        `json:"created_at,omitempty"`
+	Assignments        []Assignment      `json:"assignments,omitempty"`
+	Acknowledgements   []Acknowledgement `json:"acknowledgements,omitempty"`
+	LastStatusChangeAt *time.Time        `json:"last_status_change_at,omitempty"`
+
 	// NOTE: Depricated field, used for the Events API. The fielf will only
 	// contain the first assigned user.
 	AssignedToUser *User `json:"assigned_to_user,omitempty"`
 
+	APIObject
+
 	// TODO: add support for returned errors
 	// Error *ErrorResponse `json:"error,omitempty"`
 }
@@ -76,12 +88,26 @@ func (a *ObjectAt) UnmarshalJSON(data []byte) error {
 
 type TriggerSummary map[string]interface{}
 
+// Assignment is the assignment of an incident to a user, in the REST API v2.
+type Assignment struct {
+	At       *time.Time `json:"at,omitempty"`
+	Assignee *User      `json:"assignee,omitempty"`
+}
+
+// Acknowledgement is the acknowledgement of an incident, in the REST API v2.
+// The acknowledger is a user reference, or a service reference when the
+// incident was acknowledged through an integration.
+type Acknowledgement struct {
+	At           *time.Time `json:"at,omitempty"`
+	Acknowledger *User      `json:"acknowledger,omitempty"`
+}
+
 type IncidentListOptions struct {
 	// The start of the date range you want to search.
 	Since time.Time `url:"since,omitempty"`
 
 	// The end of the date range you want to search.
-	Until time.Time `url:"until,omitemtpy"`
+	Until time.Time `url:"until,omitempty"`
 
 	// When set to 'all', the 'since' and 'until' parameters and defaults are
 	// ignored. Unse this to get all incidents since the account was created.
@@ -94,7 +120,7 @@ type IncidentListOptions struct {
 
 	// Returns only the incidents in the passed status(es). Valid status
 	// options are 'triggered', 'acknowledged' and 'resolved'.
-	Status string `url:"status,omitemtpy"`
+	Status string `url:"status,omitempty"`
 
 	// Returns only the incidents with the passes de-duplication key.
 	IncidentKey string `url:"incidient_key,omitempty"`
@@ -130,6 +156,13 @@ type IncidentListOptions struct {
 	// 'urgency'.
 	SortBy string `url:"sort_by,omitempty"`
 
+	// REST API v2 filters, which replace the comma-separated Status, Service,
+	// Teams and AssignedToUser filters of the REST API v1.
+	Statuses   []string `url:"statuses[],omitempty"`
+	ServiceIDs []string `url:"service_ids[],omitempty"`
+	TeamIDs    []string `url:"team_ids[],omitempty"`
+	UserIDs    []string `url:"user_ids[],omitempty"`
+
 	ListOptions
 }
 
@@ -141,13 +174,18 @@ type incidentListWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/incidents/list
 func (s *IncidentsService) List(opts *IncidentListOptions) ([]Incident, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *IncidentsService) ListContext(ctx context.Context, opts *IncidentListOptions) ([]Incident, *Response, error) {
 	uri, err := addOptions("incidents", opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	incidents := new(incidentListWrapper)
-	resp, err := s.client.Get(uri, incidents)
+	resp, err := s.client.GetContext(ctx, uri, incidents)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -155,16 +193,73 @@ func (s *IncidentsService) List(opts *IncidentListOptions) ([]Incident, *Respons
 	return incidents.Incidents, resp, err
 }
 
+// ListAll fetches every incident matching opts, following the pagination of List
+// until all of them or p.Max of them are collected.
+func (s *IncidentsService) ListAll(opts *IncidentListOptions, p *PaginationOptions) ([]Incident, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *IncidentsService) ListAllContext(ctx context.Context, opts *IncidentListOptions, p *PaginationOptions) ([]Incident, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every incident matching opts.
+func (s *IncidentsService) Iter(opts *IncidentListOptions) *Iterator[Incident] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *IncidentsService) IterContext(ctx context.Context, opts *IncidentListOptions) *Iterator[Incident] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the incidents matching opts.
+func (s *IncidentsService) pager(opts *IncidentListOptions) (ListOptions, pageFetcher[Incident]) {
+	var o IncidentListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]Incident, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
+
+type incidentWrapper struct {
+	Incident *Incident `json:"incident"`
+}
+
 // Get fetches an incident by id.
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/incidents/show
 func (s *IncidentsService) Get(id string) (*Incident, *Response, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *IncidentsService) GetContext(ctx context.Context, id string) (*Incident, *Response, error) {
 	uri := fmt.Sprintf("incidents/%s", id)
 
+	if s.client.isV2() {
+		incident := new(incidentWrapper)
+		resp, err := s.client.GetContext(ctx, uri, incident)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		return incident.Incident, resp, err
+	}
+
 	incident := new(Incident)
-	resp, err := s.client.Get(uri, incident)
+	resp, err := s.client.GetContext(ctx, uri, incident)
 	if err != nil {
-		return nil, resp, nil
+		return nil, resp, err
 	}
 
 	return incident, resp, err
@@ -175,7 +270,7 @@ type IncidentCountOptions struct {
 	Since *time.Time `url:"since,omitempty"`
 
 	// The end of the date range you want to search.
-	Until *time.Time `url:"until,omitemtpy"`
+	Until *time.Time `url:"until,omitempty"`
 
 	// When set to 'all', the 'since' and 'until' parameters and defaults are
 	// ignored. Unse this to get all incidents since the account was created.
@@ -183,7 +278,7 @@ type IncidentCountOptions struct {
 
 	// Returns only the incidents in the passed status(es). Valid status
 	// options are 'triggered', 'acknowledged' and 'resolved'.
-	Status string `url:"status,omitemtpy"`
+	Status string `url:"status,omitempty"`
 
 	// Returns only the incidents with the passes de-duplication key.
 	IncidentKey string `url:"incidient_key,omitempty"`
@@ -209,6 +304,15 @@ type IncidentCountOptions struct {
 //
 // https://developer.pagerduty.com/documentation/rest/incidents/count
 func (s *IncidentsService) Count(opts *IncidentCountOptions) (int, *Response, error) {
+	return s.CountContext(context.Background(), opts)
+}
+
+// CountContext is the context aware variant of Count.
+func (s *IncidentsService) CountContext(ctx context.Context, opts *IncidentCountOptions) (int, *Response, error) {
+	if s.client.isV2() {
+		return s.countV2(ctx, opts)
+	}
+
 	uri, err := addOptions("incidents/count", opts)
 	if err != nil {
 		return 0, nil, err
@@ -217,11 +321,41 @@ func (s *IncidentsService) Count(opts *IncidentCountOptions) (int, *Response, er
 	count := new(struct {
 		Total int `json:"total"`
 	})
-	resp, err := s.client.Get(uri, count)
+	resp, err := s.client.GetContext(ctx, uri, count)
 
 	return count.Total, resp, err
 }
 
+// countV2 counts incidents using the total of a single record list, as the
+// REST API v2 has no count endpoint.
+func (s *IncidentsService) countV2(ctx context.Context, opts *IncidentCountOptions) (int, *Response, error) {
+	listOpts := &IncidentListOptions{
+		ListOptions: ListOptions{Limit: 1, Total: true},
+	}
+
+	if opts != nil {
+		if opts.Since != nil {
+			listOpts.Since = *opts.Since
+		}
+		if opts.Until != nil {
+			listOpts.Until = *opts.Until
+		}
+		listOpts.DateRange = opts.DateRange
+		listOpts.IncidentKey = opts.IncidentKey
+		listOpts.Statuses = splitIDs(opts.Status)
+		listOpts.ServiceIDs = splitIDs(opts.Service)
+		listOpts.TeamIDs = splitIDs(opts.Teams)
+		listOpts.UserIDs = splitIDs(opts.AssignedToUser)
+	}
+
+	_, resp, err := s.ListContext(ctx, listOpts)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return resp.Total, resp, err
+}
+
 type IncidentEditOptions struct {
 	// An array of incidents, including the parameters to update.
 	Incidents []IncidentParameter `json:"incidents,omitempty"`
@@ -234,6 +368,10 @@ type IncidentParameter struct {
 	// The ID of the incident.
 	ID *string `json:"id,omitempty"`
 
+	// The type of the object, required by the REST API v2. Defaults to
+	// 'incident_reference' when the client speaks the REST API v2.
+	Type *string `json:"type,omitempty"`
+
 	// The new status of the incident. Possible values are 'resolved' and
 	// 'acknowledged'.
 	Status *string `json:"status,omitempty"`
@@ -254,10 +392,27 @@ type IncidentParameter struct {
 //
 // https://developer.pagerduty.com/documentation/rest/incidents/update
 func (s *IncidentsService) Edit(opts *IncidentEditOptions) ([]Incident, *Response, error) {
+	return s.EditContext(context.Background(), opts)
+}
+
+// EditContext is the context aware variant of Edit.
+func (s *IncidentsService) EditContext(ctx context.Context, opts *IncidentEditOptions) ([]Incident, *Response, error) {
 	uri := "incidents"
 
+	if s.client.isV2() && opts != nil {
+		o := *opts
+		o.Incidents = make([]IncidentParameter, len(opts.Incidents))
+		for i, incident := range opts.Incidents {
+			if incident.Type == nil {
+				incident.Type = String(ObjectTypeIncidentReference)
+			}
+			o.Incidents[i] = incident
+		}
+		opts = &o
+	}
+
 	incidents := new(incidentListWrapper)
-	resp, err := s.client.Put(uri, opts, incidents)
+	resp, err := s.client.PutContext(ctx, uri, opts, incidents)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -266,7 +421,8 @@ func (s *IncidentsService) Edit(opts *IncidentEditOptions) ([]Incident, *Respons
 }
 
 type IncidentAcknowledgeOptions struct {
-	// The user ID of the user making the request.
+	// The user ID of the user making the request. The REST API v2 identifies
+	// the requester through Client.From instead.
 	RequesterID string `url:"requester_id"`
 }
 
@@ -274,13 +430,22 @@ type IncidentAcknowledgeOptions struct {
 //
 // https://developer.pagerduty.com/documentation/rest/incidents/acknowledge
 func (s *IncidentsService) Acknowledge(id string, opts *IncidentAcknowledgeOptions) (*Response, error) {
+	return s.AcknowledgeContext(context.Background(), id, opts)
+}
+
+// AcknowledgeContext is the context aware variant of Acknowledge.
+func (s *IncidentsService) AcknowledgeContext(ctx context.Context, id string, opts *IncidentAcknowledgeOptions) (*Response, error) {
+	if s.client.isV2() {
+		return s.updateV2(ctx, id, &incidentUpdateV2{Status: String(StatusAcknowledged)})
+	}
+
 	path := fmt.Sprintf("incidents/%s/acknowledge", id)
 	uri, err := addOptions(path, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Put(uri, nil, nil)
+	return s.client.PutContext(ctx, uri, nil, nil)
 }
 
 type IncidentReassignOptions struct {
@@ -301,17 +466,42 @@ type IncidentReassignOptions struct {
 //
 // https://developer.pagerduty.com/documentation/rest/incidents/reassign
 func (s *IncidentsService) Reassign(id string, opts *IncidentReassignOptions) (*Response, error) {
+	return s.ReassignContext(context.Background(), id, opts)
+}
+
+// ReassignContext is the context aware variant of Reassign.
+func (s *IncidentsService) ReassignContext(ctx context.Context, id string, opts *IncidentReassignOptions) (*Response, error) {
+	if s.client.isV2() {
+		update := new(incidentUpdateV2)
+		if opts != nil {
+			update.EscalationLevel = opts.EscalationLevel
+			if opts.EscalationPolicy != nil {
+				update.EscalationPolicy = NewReference(ObjectTypeEscalationPolicyReference, *opts.EscalationPolicy)
+			}
+			if opts.AssignedToUser != nil {
+				for _, userID := range splitIDs(*opts.AssignedToUser) {
+					update.Assignments = append(update.Assignments, incidentAssignment{
+						Assignee: NewReference(ObjectTypeUserReference, userID),
+					})
+				}
+			}
+		}
+
+		return s.updateV2(ctx, id, update)
+	}
+
 	path := fmt.Sprintf("incidents/%s/reassign", id)
 	uri, err := addOptions(path, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Put(uri, nil, nil)
+	return s.client.PutContext(ctx, uri, nil, nil)
 }
 
 type IncidentResolveOptions struct {
-	// The user ID of the user making the request.
+	// The user ID of the user making the request. The REST API v2 identifies
+	// the requester through Client.From instead.
 	RequesterID string `url:"requester_id"`
 }
 
@@ -319,13 +509,22 @@ type IncidentResolveOptions struct {
 //
 // https://developer.pagerduty.com/documentation/rest/incidents/resolve
 func (s *IncidentsService) Resolve(id string, opts *IncidentResolveOptions) (*Response, error) {
+	return s.ResolveContext(context.Background(), id, opts)
+}
+
+// ResolveContext is the context aware variant of Resolve.
+func (s *IncidentsService) ResolveContext(ctx context.Context, id string, opts *IncidentResolveOptions) (*Response, error) {
+	if s.client.isV2() {
+		return s.updateV2(ctx, id, &incidentUpdateV2{Status: String(StatusResolved)})
+	}
+
 	path := fmt.Sprintf("incidents/%s/resolve", id)
 	uri, err := addOptions(path, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Put(uri, nil, nil)
+	return s.client.PutContext(ctx, uri, nil, nil)
 }
 
 type IncidentSnoozeOptions struct {
@@ -341,11 +540,120 @@ type IncidentSnoozeOptions struct {
 //
 // https://developer.pagerduty.com/documentation/rest/incidents/snooze
 func (s *IncidentsService) Snooze(id string, opts *IncidentSnoozeOptions) (*Response, error) {
+	return s.SnoozeContext(context.Background(), id, opts)
+}
+
+// SnoozeContext is the context aware variant of Snooze.
+func (s *IncidentsService) SnoozeContext(ctx context.Context, id string, opts *IncidentSnoozeOptions) (*Response, error) {
 	path := fmt.Sprintf("incidents/%s/snooze", id)
+
+	if s.client.isV2() {
+		body := new(struct {
+			Duration int `json:"duration"`
+		})
+		if opts != nil {
+			body.Duration = opts.Duration
+		}
+
+		return s.client.PostContext(ctx, path, body, nil)
+	}
 	uri, err := addOptions(path, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Put(uri, nil, nil)
+	return s.client.PutContext(ctx, uri, nil, nil)
+}
+
+// incidentUpdateV2 is the body of a REST API v2 request updating a single
+// incident.
+type incidentUpdateV2 struct {
+	Type             *string              `json:"type"`
+	Status           *string              `json:"status,omitempty"`
+	EscalationLevel  *int                 `json:"escalation_level,omitempty"`
+	EscalationPolicy *Reference           `json:"escalation_policy,omitempty"`
+	Assignments      []incidentAssignment `json:"assignments,omitempty"`
+}
+
+type incidentAssignment struct {
+	Assignee *Reference `json:"assignee"`
+}
+
+// updateV2 applies update to the incident with the given id using the REST
+// API v2.
+func (s *IncidentsService) updateV2(ctx context.Context, id string, update *incidentUpdateV2) (*Response, error) {
+	uri := fmt.Sprintf("incidents/%s", id)
+
+	update.Type = String(ObjectTypeIncidentReference)
+	body := &struct {
+		Incident *incidentUpdateV2 `json:"incident"`
+	}{update}
+
+	return s.client.PutContext(ctx, uri, body, nil)
+}
+
+// Note represents a note attached to a PagerDuty incident.
+type Note struct {
+	ID        *string    `json:"id,omitempty"`
+	User      *User      `json:"user,omitempty"`
+	Content   *string    `json:"content,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type noteListWrapper struct {
+	Notes []Note `json:"notes"`
+}
+
+type noteWrapper struct {
+	Note *Note `json:"note"`
+}
+
+// ListNotes lists the notes of an incident.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/incidents/notes/list
+func (s *IncidentsService) ListNotes(incidentID string) ([]Note, *Response, error) {
+	return s.ListNotesContext(context.Background(), incidentID)
+}
+
+// ListNotesContext is the context aware variant of ListNotes.
+func (s *IncidentsService) ListNotesContext(ctx context.Context, incidentID string) ([]Note, *Response, error) {
+	uri := fmt.Sprintf("incidents/%s/notes", incidentID)
+
+	notes := new(noteListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, notes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return notes.Notes, resp, err
+}
+
+// CreateNote adds a note to an incident. requesterID is the id of the user
+// writing the note. It may be empty for a client speaking the REST API v2,
+// which identifies the requester through Client.From instead.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/incidents/notes/create
+func (s *IncidentsService) CreateNote(incidentID, content, requesterID string) (*Note, *Response, error) {
+	return s.CreateNoteContext(context.Background(), incidentID, content, requesterID)
+}
+
+// CreateNoteContext is the context aware variant of CreateNote.
+func (s *IncidentsService) CreateNoteContext(ctx context.Context, incidentID, content, requesterID string) (*Note, *Response, error) {
+	uri := fmt.Sprintf("incidents/%s/notes", incidentID)
+
+	body := &struct {
+		Note        *Note  `json:"note"`
+		RequesterID string `json:"requester_id,omitempty"`
+	}{
+		Note:        &Note{Content: String(content)},
+		RequesterID: requesterID,
+	}
+
+	note := new(noteWrapper)
+	resp, err := s.client.PostContext(ctx, uri, body, note)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return note.Note, resp, err
 }
@@ -7,13 +7,28 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"regexp"
-	// "time"
+	"time"
 )
 
 const (
+	noteListJSON = `{ "notes": [` + noteJSON + `]}`
+	noteGetJSON  = `{ "note": ` + noteJSON + `}`
+	noteJSON     = `{
+		"id": "PWL7QXS",
+		"user": {
+			"id": "PT23IWX",
+			"name": "Tim Wright",
+			"email": "tim@example.com"
+		},
+		"content": "Firefighters are on the scene.",
+		"created_at": "2013-03-06T15:28:51-05:00"
+	}`
+
 	incidentListJSON = `{ "incidents": [` + incidentJSON + `]}`
 	incidentJSON     = `{
 		"incident_number": 1 ,
@@ -57,9 +72,11 @@ var _ = Describe("Incidents", func() {
 	var (
 		env              *TestEnvironment
 		expectedIncident Incident
+		expectedNote     Note
 	)
 
 	json.Unmarshal([]byte(incidentJSON), &expectedIncident)
+	json.Unmarshal([]byte(noteJSON), &expectedNote)
 
 	BeforeEach(func() { env = NewTestEnvironment() })
 	AfterEach(func() { env.Server.Close() })
@@ -103,6 +120,56 @@ var _ = Describe("Incidents", func() {
 				Expect(incidents[0]).To(Equal(expectedIncident))
 			})
 		})
+
+		Context("with empty options", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents", ghttp.CombineHandlers(
+					ghttp.VerifyRequest(GET, "/incidents", ""),
+					ghttp.RespondWith(http.StatusOK, incidentListJSON),
+				))
+
+				incidents, resp, err = env.Client.Incidents.List(&IncidentListOptions{})
+			})
+
+			It("should not send the unset filters", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ListContext", func() {
+		var (
+			incidents []Incident
+			resp      *Response
+			err       error
+		)
+
+		Context("with a canceled context", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, incidentListJSON),
+				))
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				incidents, resp, err = env.Client.Incidents.ListContext(ctx, nil)
+			})
+
+			It("should not have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+
+			It("should return the context's error", func() {
+				Expect(err).To(Equal(context.Canceled))
+			})
+
+			It("should not return any incidents", func() {
+				Expect(resp).To(BeNil())
+				Expect(incidents).To(BeNil())
+			})
+		})
 	})
 
 	Describe("Get", func() {
@@ -183,6 +250,21 @@ var _ = Describe("Incidents", func() {
 				Expect(count).To(Equal(1))
 			})
 		})
+
+		Context("with empty options", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents/count", ghttp.CombineHandlers(
+					ghttp.VerifyRequest(GET, "/incidents/count", ""),
+					ghttp.RespondWith(http.StatusOK, `{"total": 1}`),
+				))
+
+				count, resp, err = env.Client.Incidents.Count(&IncidentCountOptions{})
+			})
+
+			It("should not send the unset filters", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("Edit", func() {
@@ -365,4 +447,357 @@ var _ = Describe("Incidents", func() {
 			})
 		})
 	})
+
+	Describe("ListNotes", func() {
+		var (
+			notes []Note
+			resp  *Response
+			err   error
+		)
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents/PIJ90N7/notes", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, noteListJSON),
+				))
+
+				notes, resp, err = env.Client.Incidents.ListNotes("PIJ90N7")
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the expected notes", func() {
+				Expect(notes).To(HaveLen(1))
+				Expect(notes[0]).To(Equal(expectedNote))
+			})
+		})
+	})
+
+	Describe("CreateNote", func() {
+		var (
+			note *Note
+			resp *Response
+			err  error
+		)
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/incidents/PIJ90N7/notes", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"note": { "content": "Firefighters are on the scene." },
+						"requester_id": "PT23IWX"
+					}`),
+					ghttp.RespondWith(http.StatusCreated, noteGetJSON),
+				))
+
+				note, resp, err = env.Client.Incidents.CreateNote("PIJ90N7", "Firefighters are on the scene.", "PT23IWX")
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a response with the correct status code", func() {
+				Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			})
+
+			It("should return the created note", func() {
+				Expect(note).To(Equal(&expectedNote))
+			})
+		})
+	})
+
+	Describe("with the REST API v2", func() {
+		var err error
+
+		BeforeEach(func() {
+			env.Server.Close()
+			env = NewTestEnvironmentV2()
+		})
+
+		Context("getting an incident", func() {
+			var incident *Incident
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents/PT4KHLK", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.RespondWith(http.StatusOK, `{
+						"incident": {
+							"id": "PT4KHLK",
+							"type": "incident",
+							"summary": "[#1234] The server is on fire.",
+							"self": "https://api.pagerduty.com/incidents/PT4KHLK",
+							"incident_number": 1234,
+							"status": "resolved",
+							"html_url": "https://subdomain.pagerduty.com/incidents/PT4KHLK"
+						}
+					}`),
+				))
+
+				incident, _, err = env.Client.Incidents.Get("PT4KHLK")
+			})
+
+			It("should decode the wrapped incident", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*incident.ID).To(Equal("PT4KHLK"))
+				Expect(*incident.Number).To(Equal(1234))
+				Expect(*incident.Type).To(Equal("incident"))
+				Expect(*incident.Summary).To(Equal("[#1234] The server is on fire."))
+				Expect(*incident.Self).To(Equal("https://api.pagerduty.com/incidents/PT4KHLK"))
+			})
+		})
+
+		Context("getting an incident with its assignments and acknowledgements", func() {
+			var incident *Incident
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents/PT4KHLK", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.RespondWith(http.StatusOK, `{
+						"incident": {
+							"id": "PT4KHLK",
+							"type": "incident",
+							"summary": "[#1234] The server is on fire.",
+							"self": "https://api.pagerduty.com/incidents/PT4KHLK",
+							"html_url": "https://subdomain.pagerduty.com/incidents/PT4KHLK",
+							"incident_number": 1234,
+							"title": "The server is on fire.",
+							"created_at": "2015-10-06T21:30:42Z",
+							"status": "acknowledged",
+							"incident_key": "baf7cf21b1da41b4b0221008339ff357",
+							"service": {
+								"id": "PIJ90N7",
+								"type": "generic_email_reference",
+								"summary": "My Mail Service",
+								"self": "https://api.pagerduty.com/services/PIJ90N7",
+								"html_url": "https://subdomain.pagerduty.com/services/PIJ90N7"
+							},
+							"assignments": [{
+								"at": "2015-11-10T00:31:52Z",
+								"assignee": {
+									"id": "PXPGF42",
+									"type": "user_reference",
+									"summary": "Earline Greenholt",
+									"self": "https://api.pagerduty.com/users/PXPGF42",
+									"html_url": "https://subdomain.pagerduty.com/users/PXPGF42"
+								}
+							}],
+							"acknowledgements": [{
+								"at": "2015-11-10T00:32:52Z",
+								"acknowledger": {
+									"id": "PXPGF42",
+									"type": "user_reference",
+									"summary": "Earline Greenholt",
+									"self": "https://api.pagerduty.com/users/PXPGF42",
+									"html_url": "https://subdomain.pagerduty.com/users/PXPGF42"
+								}
+							}],
+							"last_status_change_at": "2015-10-06T21:38:23Z",
+							"last_status_change_by": {
+								"id": "PXPGF42",
+								"type": "user_reference",
+								"summary": "Earline Greenholt",
+								"self": "https://api.pagerduty.com/users/PXPGF42",
+								"html_url": "https://subdomain.pagerduty.com/users/PXPGF42"
+							},
+							"escalation_policy": {
+								"id": "PT20YPA",
+								"type": "escalation_policy_reference",
+								"summary": "Another Escalation Policy",
+								"self": "https://api.pagerduty.com/escalation_policies/PT20YPA",
+								"html_url": "https://subdomain.pagerduty.com/escalation_policies/PT20YPA"
+							},
+							"teams": [{
+								"id": "PQ9K7I8",
+								"type": "team_reference",
+								"summary": "Engineering",
+								"self": "https://api.pagerduty.com/teams/PQ9K7I8",
+								"html_url": "https://subdomain.pagerduty.com/teams/PQ9K7I8"
+							}],
+							"urgency": "high"
+						}
+					}`),
+				))
+
+				incident, _, err = env.Client.Incidents.Get("PT4KHLK")
+			})
+
+			It("should decode the REST API v2 fields", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*incident.Title).To(Equal("The server is on fire."))
+				Expect(*incident.CreatedAt).To(Equal(time.Date(2015, time.October, 6, 21, 30, 42, 0, time.UTC)))
+				Expect(*incident.LastStatusChangeAt).To(Equal(time.Date(2015, time.October, 6, 21, 38, 23, 0, time.UTC)))
+				Expect(*incident.LastStatusChangeBy.ID).To(Equal("PXPGF42"))
+			})
+
+			It("should decode the assignments and acknowledgements", func() {
+				Expect(incident.Assignments).To(HaveLen(1))
+				Expect(*incident.Assignments[0].At).To(Equal(time.Date(2015, time.November, 10, 0, 31, 52, 0, time.UTC)))
+				Expect(*incident.Assignments[0].Assignee.ID).To(Equal("PXPGF42"))
+				Expect(*incident.Assignments[0].Assignee.Summary).To(Equal("Earline Greenholt"))
+
+				Expect(incident.Acknowledgements).To(HaveLen(1))
+				Expect(*incident.Acknowledgements[0].At).To(Equal(time.Date(2015, time.November, 10, 0, 32, 52, 0, time.UTC)))
+				Expect(*incident.Acknowledgements[0].Acknowledger.ID).To(Equal("PXPGF42"))
+				Expect(*incident.Acknowledgements[0].Acknowledger.Type).To(Equal("user_reference"))
+			})
+		})
+
+		Context("counting incidents", func() {
+			var count int
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					verifyURLQueryHandler(url.Values{
+						"limit":      []string{"1"},
+						"total":      []string{"true"},
+						"statuses[]": []string{"triggered", "acknowledged"},
+					}),
+					ghttp.RespondWith(http.StatusOK, `{ "incidents": [], "total": 42, "more": true }`),
+				))
+
+				count, _, err = env.Client.Incidents.Count(&IncidentCountOptions{
+					Status: "triggered,acknowledged",
+				})
+			})
+
+			It("should return the total of the incident list", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(42))
+			})
+		})
+
+		Context("acknowledging an incident", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/incidents/PT4KHLK", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"incident": { "type": "incident_reference", "status": "acknowledged" }
+					}`),
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+
+				_, err = env.Client.Incidents.Acknowledge("PT4KHLK", nil)
+			})
+
+			It("should update the incident status", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("editing incidents", func() {
+			var opts *IncidentEditOptions
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/incidents", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"incidents": [{ "id": "PT4KHLK", "type": "incident_reference", "status": "resolved" }]
+					}`),
+					ghttp.RespondWith(http.StatusOK, `{ "incidents": [] }`),
+				))
+
+				opts = &IncidentEditOptions{
+					Incidents: []IncidentParameter{{ID: String("PT4KHLK"), Status: String("resolved")}},
+				}
+				_, _, err = env.Client.Incidents.Edit(opts)
+			})
+
+			It("should send the incident references", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should leave the options untouched", func() {
+				Expect(opts.Incidents[0].Type).To(BeNil())
+			})
+		})
+
+		Context("reassigning an incident", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/incidents/PT4KHLK", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"incident": {
+							"type": "incident_reference",
+							"assignments": [
+								{ "assignee": { "id": "P1", "type": "user_reference" } },
+								{ "assignee": { "id": "P2", "type": "user_reference" } }
+							]
+						}
+					}`),
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+
+				_, err = env.Client.Incidents.Reassign("PT4KHLK", &IncidentReassignOptions{
+					AssignedToUser: String("P1,P2"),
+				})
+			})
+
+			It("should update the incident assignments", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("snoozing an incident", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/incidents/PT4KHLK/snooze", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{ "duration": 3600 }`),
+					ghttp.RespondWith(http.StatusCreated, nil),
+				))
+
+				_, err = env.Client.Incidents.Snooze("PT4KHLK", &IncidentSnoozeOptions{Duration: 3600})
+			})
+
+			It("should post the snooze duration", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("listing every incident", func() {
+			var incidents []Incident
+
+			BeforeEach(func() {
+				env.Server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{
+						"incidents": [{ "id": "P1" }, { "id": "P2" }],
+						"offset": 0, "limit": 2, "more": true
+					}`),
+					ghttp.RespondWith(http.StatusOK, `{
+						"incidents": [{ "id": "P3" }, { "id": "P4" }],
+						"offset": 2, "limit": 2, "more": false
+					}`),
+				)
+
+				incidents, err = env.Client.Incidents.ListAll(nil, nil)
+			})
+
+			It("should follow the more flag", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(incidents).To(HaveLen(4))
+			})
+		})
+	})
 })
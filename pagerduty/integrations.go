@@ -0,0 +1,161 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	IntegrationTypeEmail       = "generic_email_inbound_integration"
+	IntegrationTypeEventsAPI   = "generic_events_api_inbound_integration"
+	IntegrationTypeEventsAPIV2 = "events_api_v2_inbound_integration"
+)
+
+// Integration represents an integration of a PagerDuty service, through which
+// a monitoring tool or an email address sends events to the service.
+// Integrations are only available in the REST API v2.
+type Integration struct {
+	ID               *string    `json:"id,omitempty"`
+	Name             *string    `json:"name,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	Service          *Service   `json:"service,omitempty"`
+	Vendor           *Vendor    `json:"vendor,omitempty"`
+	IntegrationKey   *string    `json:"integration_key,omitempty"`
+	IntegrationEmail *string    `json:"integration_email,omitempty"`
+
+	APIObject
+}
+
+// Vendor represents the monitoring tool sending the events of an integration.
+type Vendor struct {
+	ID   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+
+	APIObject
+}
+
+// NewVendorReference returns a reference to the vendor with the given id, to
+// be used when creating an integration.
+func NewVendorReference(id string) *Vendor {
+	return &Vendor{
+		ID:        String(id),
+		APIObject: APIObject{Type: String(ObjectTypeVendorReference)},
+	}
+}
+
+type integrationWrapper struct {
+	Integration *Integration `json:"integration"`
+}
+
+type GetIntegrationOptions struct {
+	// Array of additional details to include. Possible values are 'services'
+	// and 'vendors'.
+	Include []string `url:"include[],omitempty"`
+}
+
+// CreateIntegration creates an integration on a service. The integration key
+// events are sent with is returned in the IntegrationKey of the integration.
+//
+// PagerDuty API docs: https://v2.developer.pagerduty.com/v2/page/api-reference#!/Services/post_services_id_integrations
+func (s *ServicesService) CreateIntegration(serviceID string, integration *Integration) (*Integration, *Response, error) {
+	return s.CreateIntegrationContext(context.Background(), serviceID, integration)
+}
+
+// CreateIntegrationContext is the context aware variant of CreateIntegration.
+func (s *ServicesService) CreateIntegrationContext(ctx context.Context, serviceID string, integration *Integration) (*Integration, *Response, error) {
+	if !s.client.isV2() {
+		return nil, nil, errRequiresV2("Services.CreateIntegration")
+	}
+
+	if integration == nil {
+		return nil, nil, fmt.Errorf("pagerduty: integration cannot be nil")
+	}
+
+	uri := fmt.Sprintf("services/%s/integrations", serviceID)
+
+	i := new(integrationWrapper)
+	resp, err := s.client.PostContext(ctx, uri, &integrationWrapper{Integration: integration}, i)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return i.Integration, resp, err
+}
+
+// GetIntegration fetches an integration of a service by id and filtered by
+// provided options.
+//
+// PagerDuty API docs: https://v2.developer.pagerduty.com/v2/page/api-reference#!/Services/get_services_id_integrations_integration_id
+func (s *ServicesService) GetIntegration(serviceID, id string, opts *GetIntegrationOptions) (*Integration, *Response, error) {
+	return s.GetIntegrationContext(context.Background(), serviceID, id, opts)
+}
+
+// GetIntegrationContext is the context aware variant of GetIntegration.
+func (s *ServicesService) GetIntegrationContext(ctx context.Context, serviceID, id string, opts *GetIntegrationOptions) (*Integration, *Response, error) {
+	if !s.client.isV2() {
+		return nil, nil, errRequiresV2("Services.GetIntegration")
+	}
+
+	path := fmt.Sprintf("services/%s/integrations/%s", serviceID, id)
+	uri, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i := new(integrationWrapper)
+	resp, err := s.client.GetContext(ctx, uri, i)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return i.Integration, resp, err
+}
+
+// GetIntegrationKey fetches the key events are sent with to an integration of
+// a service.
+func (s *ServicesService) GetIntegrationKey(serviceID, id string) (string, *Response, error) {
+	return s.GetIntegrationKeyContext(context.Background(), serviceID, id)
+}
+
+// GetIntegrationKeyContext is the context aware variant of GetIntegrationKey.
+func (s *ServicesService) GetIntegrationKeyContext(ctx context.Context, serviceID, id string) (string, *Response, error) {
+	integration, resp, err := s.GetIntegrationContext(ctx, serviceID, id, nil)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if integration == nil || integration.IntegrationKey == nil {
+		return "", resp, fmt.Errorf("pagerduty: integration %s has no integration key", id)
+	}
+
+	return *integration.IntegrationKey, resp, nil
+}
+
+// UpdateIntegration updates an existing integration of a service.
+//
+// PagerDuty API docs: https://v2.developer.pagerduty.com/v2/page/api-reference#!/Services/put_services_id_integrations_integration_id
+func (s *ServicesService) UpdateIntegration(serviceID string, integration *Integration) (*Integration, *Response, error) {
+	return s.UpdateIntegrationContext(context.Background(), serviceID, integration)
+}
+
+// UpdateIntegrationContext is the context aware variant of UpdateIntegration.
+func (s *ServicesService) UpdateIntegrationContext(ctx context.Context, serviceID string, integration *Integration) (*Integration, *Response, error) {
+	if !s.client.isV2() {
+		return nil, nil, errRequiresV2("Services.UpdateIntegration")
+	}
+
+	if integration == nil || integration.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: integration id cannot be nil")
+	}
+
+	uri := fmt.Sprintf("services/%s/integrations/%s", serviceID, *integration.ID)
+
+	i := new(integrationWrapper)
+	resp, err := s.client.PutContext(ctx, uri, &integrationWrapper{Integration: integration}, i)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return i.Integration, resp, err
+}
@@ -0,0 +1,182 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+const (
+	integrationGetJSON = `{ "integration": ` + integrationJSON + `}`
+	integrationJSON    = `{
+		"id": "PE1U9CH",
+		"type": "generic_events_api_inbound_integration",
+		"name": "Datadog",
+		"created_at": "2015-11-09T20:00:00Z",
+		"service": {
+			"id": "PIJ90N7",
+			"type": "service_reference"
+		},
+		"vendor": {
+			"id": "PAM4FGS",
+			"type": "vendor_reference"
+		},
+		"integration_key": "f4b34c1cc1eb4e3bab6d49e12ef9e6e1"
+	}`
+)
+
+var _ = Describe("Integrations", func() {
+	var (
+		env                 *TestEnvironment
+		expectedIntegration Integration
+
+		integration *Integration
+		resp        *Response
+		err         error
+	)
+
+	json.Unmarshal([]byte(integrationJSON), &expectedIntegration)
+
+	BeforeEach(func() { env = NewTestEnvironmentV2() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("CreateIntegration", func() {
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/services/PIJ90N7/integrations", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"integration": {
+							"type": "generic_events_api_inbound_integration",
+							"name": "Datadog",
+							"vendor": { "id": "PAM4FGS", "type": "vendor_reference" }
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, integrationGetJSON),
+				))
+
+				integration, resp, err = env.Client.Services.CreateIntegration("PIJ90N7", &Integration{
+					Name:      String("Datadog"),
+					Vendor:    NewVendorReference("PAM4FGS"),
+					APIObject: APIObject{Type: String(IntegrationTypeEventsAPI)},
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the created integration", func() {
+				Expect(integration).To(Equal(&expectedIntegration))
+			})
+		})
+
+		Context("with the REST API v1", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironment()
+
+				integration, resp, err = env.Client.Services.CreateIntegration("PIJ90N7", &Integration{})
+			})
+
+			It("should return an error without making a request", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetIntegration", func() {
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/services/PIJ90N7/integrations/PE1U9CH", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					verifyURLQueryHandler(url.Values{"include[]": []string{"vendors"}}),
+					ghttp.RespondWith(http.StatusOK, integrationGetJSON),
+				))
+
+				integration, resp, err = env.Client.Services.GetIntegration("PIJ90N7", "PE1U9CH", &GetIntegrationOptions{
+					Include: []string{"vendors"},
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the expected integration", func() {
+				Expect(integration).To(Equal(&expectedIntegration))
+			})
+		})
+	})
+
+	Describe("GetIntegrationKey", func() {
+		It("should return the key of the integration", func() {
+			env.Server.RouteToHandler(GET, "/services/PIJ90N7/integrations/PE1U9CH", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.RespondWith(http.StatusOK, integrationGetJSON),
+			))
+
+			key, _, err := env.Client.Services.GetIntegrationKey("PIJ90N7", "PE1U9CH")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("f4b34c1cc1eb4e3bab6d49e12ef9e6e1"))
+		})
+
+		It("should return an error for an integration without a key", func() {
+			env.Server.RouteToHandler(GET, "/services/PIJ90N7/integrations/PE1U9CH", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.RespondWith(http.StatusOK, `{ "integration": { "id": "PE1U9CH" } }`),
+			))
+
+			_, _, err := env.Client.Services.GetIntegrationKey("PIJ90N7", "PE1U9CH")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UpdateIntegration", func() {
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/services/PIJ90N7/integrations/PE1U9CH", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{ "integration": { "id": "PE1U9CH", "name": "Datadog" } }`),
+					ghttp.RespondWith(http.StatusOK, integrationGetJSON),
+				))
+
+				integration, resp, err = env.Client.Services.UpdateIntegration("PIJ90N7", &Integration{
+					ID:   String("PE1U9CH"),
+					Name: String("Datadog"),
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the updated integration", func() {
+				Expect(integration).To(Equal(&expectedIntegration))
+			})
+		})
+	})
+})
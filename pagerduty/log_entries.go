@@ -0,0 +1,309 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	LogEntryTypeAcknowledge = "acknowledge"
+	LogEntryTypeAnnotate    = "annotate"
+	LogEntryTypeAssign      = "assign"
+	LogEntryTypeEscalate    = "escalate"
+	LogEntryTypeNotify      = "notify"
+	LogEntryTypeResolve     = "resolve"
+	LogEntryTypeTrigger     = "trigger"
+
+	LogEntryIncludeChannel  = "channel"
+	LogEntryIncludeIncident = "incident"
+	LogEntryIncludeService  = "service"
+
+	// suffix of the log entry types of the REST API v2
+	logEntryTypeV2Suffix = "_log_entry"
+)
+
+// LogEntriesService handles communication with the Log Entries related
+// methods of the PagerDuty API.
+type LogEntriesService struct {
+	client *Client
+}
+
+// LogEntry represents an event on an incident's timeline, such as a
+// notification sent to a user or an escalation.
+type LogEntry struct {
+	ID        *string          `json:"id,omitempty"`
+	Type      *string          `json:"type,omitempty"`
+	CreatedAt *time.Time       `json:"created_at,omitempty"`
+	Agent     *LogEntryAgent   `json:"agent,omitempty"`
+	Channel   *LogEntryChannel `json:"channel,omitempty"`
+	Incident  *Incident        `json:"incident,omitempty"`
+	Service   *Service         `json:"service,omitempty"`
+
+	// Details holds the fields specific to the type of the log entry. It is
+	// one of *TriggerLogEntry, *NotifyLogEntry, *AcknowledgeLogEntry,
+	// *EscalateLogEntry, *AssignLogEntry, *ResolveLogEntry or
+	// *AnnotateLogEntry, or nil for the other types.
+	Details interface{} `json:"-"`
+}
+
+// LogEntryAgent is the user, service or integration that caused a log entry.
+type LogEntryAgent struct {
+	ID    *string `json:"id,omitempty"`
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+
+	APIObject
+}
+
+// LogEntryChannel describes how the action of a log entry was performed,
+// e.g. through the web UI, an email or the Events API. It is only returned
+// when 'channel' is included.
+type LogEntryChannel struct {
+	Type        *string     `json:"type,omitempty"`
+	Summary     *string     `json:"summary,omitempty"`
+	Subject     *string     `json:"subject,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	Details     interface{} `json:"details,omitempty"`
+}
+
+// LogEntryNotification describes a notification sent to a user.
+type LogEntryNotification struct {
+	Type    *string `json:"type,omitempty"`
+	Status  *string `json:"status,omitempty"`
+	Address *string `json:"address,omitempty"`
+}
+
+// TriggerLogEntry holds the fields specific to 'trigger' log entries.
+type TriggerLogEntry struct{}
+
+// NotifyLogEntry holds the fields specific to 'notify' log entries.
+type NotifyLogEntry struct {
+	User         *User                 `json:"user,omitempty"`
+	Notification *LogEntryNotification `json:"notification,omitempty"`
+}
+
+// AcknowledgeLogEntry holds the fields specific to 'acknowledge' log entries.
+type AcknowledgeLogEntry struct {
+	AcknowledgementTimeout *int `json:"acknowledgement_timeout,omitempty"`
+}
+
+// EscalateLogEntry holds the fields specific to 'escalate' log entries.
+type EscalateLogEntry struct {
+	AssignedUser    *User `json:"assigned_user,omitempty"`
+	EscalationLevel *int  `json:"escalation_level,omitempty"`
+}
+
+// AssignLogEntry holds the fields specific to 'assign' log entries.
+type AssignLogEntry struct {
+	AssignedUser  *User  `json:"assigned_user,omitempty"`
+	AssignedUsers []User `json:"assigned_users,omitempty"`
+}
+
+// ResolveLogEntry holds the fields specific to 'resolve' log entries.
+type ResolveLogEntry struct{}
+
+// AnnotateLogEntry holds the fields specific to 'annotate' log entries.
+type AnnotateLogEntry struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// Kind returns the type of the log entry without the '_log_entry' suffix
+// used by the REST API v2, so that it can be compared to the LogEntryType
+// constants regardless of the API version.
+func (e *LogEntry) Kind() string {
+	if e.Type == nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(*e.Type, logEntryTypeV2Suffix)
+}
+
+func (e *LogEntry) UnmarshalJSON(data []byte) error {
+	type alias LogEntry
+	temp := new(alias)
+	err := json.Unmarshal(data, temp)
+	if err != nil {
+		return err
+	}
+
+	*e = LogEntry(*temp)
+
+	switch e.Kind() {
+	case LogEntryTypeTrigger:
+		e.Details = new(TriggerLogEntry)
+	case LogEntryTypeNotify:
+		e.Details = new(NotifyLogEntry)
+	case LogEntryTypeAcknowledge:
+		e.Details = new(AcknowledgeLogEntry)
+	case LogEntryTypeEscalate:
+		e.Details = new(EscalateLogEntry)
+	case LogEntryTypeAssign:
+		e.Details = new(AssignLogEntry)
+	case LogEntryTypeResolve:
+		e.Details = new(ResolveLogEntry)
+	case LogEntryTypeAnnotate:
+		e.Details = new(AnnotateLogEntry)
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(data, e.Details)
+}
+
+type LogEntryListOptions struct {
+	// Time zone in which dates in the result will be rendered. Defaults to
+	// account time zone.
+	TimeZone *TimeZone `url:"time_zone,omitempty"`
+
+	// The start of the date range you want to search.
+	Since time.Time `url:"since,omitempty"`
+
+	// The end of the date range you want to search.
+	Until time.Time `url:"until,omitempty"`
+
+	// If true, only show the most important changes to the incidents, such as
+	// triggers, acknowledges and resolves.
+	IsOverview bool `url:"is_overview,omitempty"`
+
+	// Array of additional details to include. Possible values are 'channel',
+	// 'incident' and 'service'.
+	Include []string `url:"include[],omitempty"`
+
+	ListOptions
+}
+
+type logEntryListWrapper struct {
+	LogEntries []LogEntry `json:"log_entries"`
+}
+
+// List the log entries of every incident of the account, filtered by the
+// provided options.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/log_entries/list
+func (s *LogEntriesService) List(opts *LogEntryListOptions) ([]LogEntry, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *LogEntriesService) ListContext(ctx context.Context, opts *LogEntryListOptions) ([]LogEntry, *Response, error) {
+	return s.list(ctx, "log_entries", opts)
+}
+
+// ListAll fetches every log entry matching opts, following the pagination of
+// List until all of them or p.Max of them are collected.
+func (s *LogEntriesService) ListAll(opts *LogEntryListOptions, p *PaginationOptions) ([]LogEntry, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *LogEntriesService) ListAllContext(ctx context.Context, opts *LogEntryListOptions, p *PaginationOptions) ([]LogEntry, error) {
+	start, fetch := s.pager("log_entries", opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every log entry matching opts.
+func (s *LogEntriesService) Iter(opts *LogEntryListOptions) *Iterator[LogEntry] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *LogEntriesService) IterContext(ctx context.Context, opts *LogEntryListOptions) *Iterator[LogEntry] {
+	start, fetch := s.pager("log_entries", opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// ListByIncident lists the log entries of an incident, filtered by the
+// provided options.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/log_entries/incident_log_entries
+func (s *LogEntriesService) ListByIncident(incidentID string, opts *LogEntryListOptions) ([]LogEntry, *Response, error) {
+	return s.ListByIncidentContext(context.Background(), incidentID, opts)
+}
+
+// ListByIncidentContext is the context aware variant of ListByIncident.
+func (s *LogEntriesService) ListByIncidentContext(ctx context.Context, incidentID string, opts *LogEntryListOptions) ([]LogEntry, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("incidents/%s/log_entries", incidentID), opts)
+}
+
+// ListAllByIncident fetches every log entry of an incident, following the
+// pagination of ListByIncident.
+func (s *LogEntriesService) ListAllByIncident(incidentID string, opts *LogEntryListOptions, p *PaginationOptions) ([]LogEntry, error) {
+	return s.ListAllByIncidentContext(context.Background(), incidentID, opts, p)
+}
+
+// ListAllByIncidentContext is the context aware variant of ListAllByIncident.
+func (s *LogEntriesService) ListAllByIncidentContext(ctx context.Context, incidentID string, opts *LogEntryListOptions, p *PaginationOptions) ([]LogEntry, error) {
+	start, fetch := s.pager(fmt.Sprintf("incidents/%s/log_entries", incidentID), opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+func (s *LogEntriesService) list(ctx context.Context, path string, opts *LogEntryListOptions) ([]LogEntry, *Response, error) {
+	uri, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := new(logEntryListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, entries)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return entries.LogEntries, resp, err
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the log entries at path matching opts.
+func (s *LogEntriesService) pager(path string, opts *LogEntryListOptions) (ListOptions, pageFetcher[LogEntry]) {
+	var o LogEntryListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]LogEntry, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.list(ctx, path, &o)
+	}
+}
+
+type GetLogEntryOptions struct {
+	// Time zone in which dates in the result will be rendered. Defaults to
+	// account time zone.
+	TimeZone *TimeZone `url:"time_zone,omitempty"`
+
+	// Array of additional details to include. Possible values are 'channel',
+	// 'incident' and 'service'.
+	Include []string `url:"include[],omitempty"`
+}
+
+type logEntryWrapper struct {
+	LogEntry *LogEntry `json:"log_entry"`
+}
+
+// Get fetches a log entry by id and filtered by provided options.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/log_entries/show
+func (s *LogEntriesService) Get(id string, opts *GetLogEntryOptions) (*LogEntry, *Response, error) {
+	return s.GetContext(context.Background(), id, opts)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *LogEntriesService) GetContext(ctx context.Context, id string, opts *GetLogEntryOptions) (*LogEntry, *Response, error) {
+	path := fmt.Sprintf("log_entries/%s", id)
+	uri, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry := new(logEntryWrapper)
+	resp, err := s.client.GetContext(ctx, uri, entry)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return entry.LogEntry, resp, err
+}
@@ -0,0 +1,201 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+const (
+	logEntryListJSON = `{ "log_entries": [` +
+		logEntryJSON + `,` +
+		notifyLogEntryJSON + `,` +
+		assignLogEntryJSON + `,` +
+		annotateLogEntryJSON + `]}`
+	logEntryGetJSON = `{ "log_entry": ` + logEntryJSON + `}`
+	logEntryJSON    = `{
+		"id": "PUSGJWV",
+		"type": "trigger",
+		"created_at": "2015-11-20T22:52:37Z",
+		"agent": {
+			"type": "service",
+			"id": "PSCOOCO",
+			"name": "service"
+		},
+		"channel": {
+			"type": "api",
+			"summary": "summary",
+			"service_key": "key"
+		},
+		"incident": {
+			"id": "PT4KHLK"
+		}
+	}`
+	notifyLogEntryJSON = `{
+		"id": "P2NB2JI",
+		"type": "notify",
+		"created_at": "2015-11-20T22:52:38Z",
+		"user": {
+			"id": "PT23IWX",
+			"name": "Tim Wright"
+		},
+		"notification": {
+			"type": "sms",
+			"status": "success",
+			"address": "+1 555 555 5555"
+		}
+	}`
+	assignLogEntryJSON = `{
+		"id": "P7TY2ZM",
+		"type": "assign_log_entry",
+		"created_at": "2015-11-20T22:52:39Z",
+		"assigned_user": {
+			"id": "PT23IWX"
+		}
+	}`
+	annotateLogEntryJSON = `{
+		"id": "PYLTS7Z",
+		"type": "annotate",
+		"created_at": "2015-11-20T22:52:40Z",
+		"note": "Firefighters are on the scene."
+	}`
+)
+
+var _ = Describe("LogEntries", func() {
+	var (
+		env              *TestEnvironment
+		expectedLogEntry LogEntry
+
+		resp *Response
+		err  error
+	)
+
+	json.Unmarshal([]byte(logEntryJSON), &expectedLogEntry)
+
+	BeforeEach(func() { env = NewTestEnvironment() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("List", func() {
+		Context("with a successful, non-empty response", func() {
+			var entries []LogEntry
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/log_entries", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyURLQueryHandler(url.Values{
+						"include[]": []string{LogEntryIncludeChannel},
+					}),
+					ghttp.RespondWith(http.StatusOK, logEntryListJSON),
+				))
+
+				entries, resp, err = env.Client.LogEntries.List(&LogEntryListOptions{
+					Include: []string{LogEntryIncludeChannel},
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the expected log entries", func() {
+				Expect(entries).To(HaveLen(4))
+				Expect(entries[0]).To(Equal(expectedLogEntry))
+			})
+
+			It("should decode the channel of the log entries", func() {
+				Expect(*entries[0].Channel.Type).To(Equal("api"))
+				Expect(*entries[0].Agent.Type).To(Equal("service"))
+			})
+
+			It("should decode the typed details of each log entry", func() {
+				Expect(entries[0].Details).To(BeAssignableToTypeOf(new(TriggerLogEntry)))
+
+				notify, ok := entries[1].Details.(*NotifyLogEntry)
+				Expect(ok).To(BeTrue())
+				Expect(*notify.User.ID).To(Equal("PT23IWX"))
+				Expect(*notify.Notification.Type).To(Equal("sms"))
+
+				assign, ok := entries[2].Details.(*AssignLogEntry)
+				Expect(ok).To(BeTrue())
+				Expect(*assign.AssignedUser.ID).To(Equal("PT23IWX"))
+
+				annotate, ok := entries[3].Details.(*AnnotateLogEntry)
+				Expect(ok).To(BeTrue())
+				Expect(*annotate.Note).To(Equal("Firefighters are on the scene."))
+			})
+
+			It("should normalize the REST API v2 log entry types", func() {
+				Expect(entries[2].Kind()).To(Equal(LogEntryTypeAssign))
+			})
+		})
+	})
+
+	Describe("ListByIncident", func() {
+		Context("with a successful, non-empty response", func() {
+			var entries []LogEntry
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/incidents/PT4KHLK/log_entries", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, logEntryListJSON),
+				))
+
+				entries, resp, err = env.Client.LogEntries.ListByIncident("PT4KHLK", nil)
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the expected log entries", func() {
+				Expect(entries).To(HaveLen(4))
+				Expect(entries[0]).To(Equal(expectedLogEntry))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		Context("with a successful, non-empty response", func() {
+			var entry *LogEntry
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/log_entries/PUSGJWV", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, logEntryGetJSON),
+				))
+
+				entry, resp, err = env.Client.LogEntries.Get("PUSGJWV", nil)
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the expected log entry", func() {
+				Expect(entry).To(Equal(&expectedLogEntry))
+			})
+		})
+	})
+})
@@ -0,0 +1,275 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	MaintenanceWindowFilterOngoing = "ongoing"
+	MaintenanceWindowFilterFuture  = "future"
+	MaintenanceWindowFilterPast    = "past"
+)
+
+// MaintenanceWindowsService handles communication with the Maintenance Windows
+// related methods of the PagerDuty API.
+type MaintenanceWindowsService struct {
+	client *Client
+}
+
+// MaintenanceWindow represents a PagerDuty maintenance window, during which
+// the incidents of its services are not created.
+type MaintenanceWindow struct {
+	ID             *string    `json:"id,omitempty"`
+	SequenceNumber *int       `json:"sequence_number,omitempty"`
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	EndTime        *time.Time `json:"end_time,omitempty"`
+	Description    *string    `json:"description,omitempty"`
+	CreatedBy      *User      `json:"created_by,omitempty"`
+	Services       []Service  `json:"services,omitempty"`
+	Teams          []Team     `json:"teams,omitempty"`
+
+	// The ids of the services to put in maintenance, used when creating or
+	// updating a window. The REST API v2 receives them as service references.
+	ServiceIDs []string `json:"service_ids,omitempty"`
+
+	APIObject
+}
+
+type MaintenanceWindowListOptions struct {
+	// Filters the results, showing only the maintenance windows whose
+	// descriptions contain the query.
+	Query string `url:"query,omitempty"`
+
+	// A comma-separated list of service IDs, specifying services whose
+	// maintenance windows will be returned.
+	Services string `url:"service_ids,omitempty"`
+
+	// A comma-separated list of team IDs, specifying teams whose maintenance
+	// windows will be returned.
+	Teams string `url:"teams,omitempty"`
+
+	// Only return maintenance windows that are 'ongoing', 'future' or 'past'.
+	Filter string `url:"filter,omitempty"`
+
+	// REST API v2 filters, which replace the comma-separated Services and
+	// Teams filters of the REST API v1.
+	ServiceIDs []string `url:"service_ids[],omitempty"`
+	TeamIDs    []string `url:"team_ids[],omitempty"`
+
+	ListOptions
+}
+
+type maintenanceWindowListWrapper struct {
+	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows"`
+}
+
+// List maintenance windows filtered by provided options.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/maintenance_windows/list
+func (s *MaintenanceWindowsService) List(opts *MaintenanceWindowListOptions) ([]MaintenanceWindow, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *MaintenanceWindowsService) ListContext(ctx context.Context, opts *MaintenanceWindowListOptions) ([]MaintenanceWindow, *Response, error) {
+	uri, err := addOptions("maintenance_windows", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	windows := new(maintenanceWindowListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, windows)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return windows.MaintenanceWindows, resp, err
+}
+
+// ListAll fetches every maintenance window matching opts, following the
+// pagination of List until all of them or p.Max of them are collected.
+func (s *MaintenanceWindowsService) ListAll(opts *MaintenanceWindowListOptions, p *PaginationOptions) ([]MaintenanceWindow, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *MaintenanceWindowsService) ListAllContext(ctx context.Context, opts *MaintenanceWindowListOptions, p *PaginationOptions) ([]MaintenanceWindow, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every maintenance window
+// matching opts.
+func (s *MaintenanceWindowsService) Iter(opts *MaintenanceWindowListOptions) *Iterator[MaintenanceWindow] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *MaintenanceWindowsService) IterContext(ctx context.Context, opts *MaintenanceWindowListOptions) *Iterator[MaintenanceWindow] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the maintenance windows matching opts.
+func (s *MaintenanceWindowsService) pager(opts *MaintenanceWindowListOptions) (ListOptions, pageFetcher[MaintenanceWindow]) {
+	var o MaintenanceWindowListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]MaintenanceWindow, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
+
+type maintenanceWindowWrapper struct {
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window"`
+}
+
+// Get fetches a maintenance window by id.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/maintenance_windows/show
+func (s *MaintenanceWindowsService) Get(id string) (*MaintenanceWindow, *Response, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *MaintenanceWindowsService) GetContext(ctx context.Context, id string) (*MaintenanceWindow, *Response, error) {
+	uri := fmt.Sprintf("maintenance_windows/%s", id)
+
+	window := new(maintenanceWindowWrapper)
+	resp, err := s.client.GetContext(ctx, uri, window)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return window.MaintenanceWindow, resp, err
+}
+
+// body returns the request body creating or updating window.
+func (s *MaintenanceWindowsService) body(window *MaintenanceWindow, requesterID string) interface{} {
+	if s.client.isV2() && len(window.ServiceIDs) > 0 {
+		w := *window
+		w.ServiceIDs = nil
+		for _, id := range window.ServiceIDs {
+			w.Services = append(w.Services, Service{
+				ID:        String(id),
+				APIObject: APIObject{Type: String(ObjectTypeServiceReference)},
+			})
+		}
+		window = &w
+	}
+
+	return &struct {
+		MaintenanceWindow *MaintenanceWindow `json:"maintenance_window"`
+		RequesterID       string             `json:"requester_id,omitempty"`
+	}{window, requesterID}
+}
+
+// Create a maintenance window for the services in window.ServiceIDs.
+// requesterID is the id of the user creating the window. It may be empty for
+// a client speaking the REST API v2, which identifies the requester through
+// Client.From instead.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/maintenance_windows/create
+func (s *MaintenanceWindowsService) Create(window *MaintenanceWindow, requesterID string) (*MaintenanceWindow, *Response, error) {
+	return s.CreateContext(context.Background(), window, requesterID)
+}
+
+// CreateContext is the context aware variant of Create.
+func (s *MaintenanceWindowsService) CreateContext(ctx context.Context, window *MaintenanceWindow, requesterID string) (*MaintenanceWindow, *Response, error) {
+	if window == nil {
+		return nil, nil, fmt.Errorf("pagerduty: maintenance window cannot be nil")
+	}
+
+	uri := "maintenance_windows"
+
+	w := new(maintenanceWindowWrapper)
+	resp, err := s.client.PostContext(ctx, uri, s.body(window, requesterID), w)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return w.MaintenanceWindow, resp, err
+}
+
+// Update an existing maintenance window.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/maintenance_windows/update
+func (s *MaintenanceWindowsService) Update(window *MaintenanceWindow) (*MaintenanceWindow, *Response, error) {
+	return s.UpdateContext(context.Background(), window)
+}
+
+// UpdateContext is the context aware variant of Update.
+func (s *MaintenanceWindowsService) UpdateContext(ctx context.Context, window *MaintenanceWindow) (*MaintenanceWindow, *Response, error) {
+	if window == nil || window.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: maintenance window id cannot be nil")
+	}
+
+	uri := fmt.Sprintf("maintenance_windows/%s", *window.ID)
+
+	w := new(maintenanceWindowWrapper)
+	resp, err := s.client.PutContext(ctx, uri, s.body(window, ""), w)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return w.MaintenanceWindow, resp, err
+}
+
+// Delete a maintenance window if it is in the future, or end it if it is
+// ongoing.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/maintenance_windows/delete
+func (s *MaintenanceWindowsService) Delete(id string) (*Response, error) {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is the context aware variant of Delete.
+func (s *MaintenanceWindowsService) DeleteContext(ctx context.Context, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("maintenance_windows/%s", id))
+}
+
+// Run creates a maintenance window for the services of window, calls fn and
+// ends the window once fn returns, even if fn panics or ctx is canceled. The
+// window is left alone if it already ended by itself. If window.StartTime is
+// nil, the window starts immediately.
+//
+// The error returned by fn takes precedence over the error ending the window.
+func (s *MaintenanceWindowsService) Run(ctx context.Context, window *MaintenanceWindow, requesterID string, fn func(ctx context.Context) error) (err error) {
+	if window == nil || window.EndTime == nil {
+		return fmt.Errorf("pagerduty: maintenance window end time cannot be nil")
+	}
+
+	w := *window
+	if w.StartTime == nil {
+		w.StartTime = Time(time.Now())
+	}
+
+	created, _, err := s.CreateContext(ctx, &w, requesterID)
+	if err != nil {
+		return err
+	}
+	if created == nil || created.ID == nil {
+		return fmt.Errorf("pagerduty: created maintenance window has no id")
+	}
+
+	defer func() {
+		if created.EndTime != nil && !time.Now().Before(*created.EndTime) {
+			return
+		}
+
+		// end the window even when ctx was canceled
+		_, deleteErr := s.DeleteContext(context.WithoutCancel(ctx), *created.ID)
+		if err == nil {
+			err = deleteErr
+		}
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,334 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	maintenanceWindowListJSON = `{ "maintenance_windows": [` + maintenanceWindowJSON + `]}`
+	maintenanceWindowGetJSON  = `{ "maintenance_window": ` + maintenanceWindowJSON + `}`
+	maintenanceWindowJSON     = `{
+		"id": "PFCVPS0",
+		"sequence_number": 1,
+		"start_time": "2015-11-09T20:00:00Z",
+		"end_time": "2015-11-09T22:00:00Z",
+		"description": "Immanentizing the eschaton",
+		"created_by": {
+			"id": "PT23IWX",
+			"name": "Tim Wright"
+		},
+		"services": [
+			{
+				"id": "PIJ90N7",
+				"name": "Production XDB Cluster"
+			}
+		]
+	}`
+)
+
+var _ = Describe("MaintenanceWindows", func() {
+	var (
+		env                       *TestEnvironment
+		expectedMaintenanceWindow MaintenanceWindow
+
+		resp *Response
+		err  error
+	)
+
+	json.Unmarshal([]byte(maintenanceWindowJSON), &expectedMaintenanceWindow)
+
+	BeforeEach(func() { env = NewTestEnvironment() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("List", func() {
+		Context("with a successful, non-empty response", func() {
+			var windows []MaintenanceWindow
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/maintenance_windows", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyURLQueryHandler(url.Values{
+						"filter":      []string{MaintenanceWindowFilterOngoing},
+						"service_ids": []string{"PIJ90N7,PF9KMXH"},
+					}),
+					ghttp.RespondWith(http.StatusOK, maintenanceWindowListJSON),
+				))
+
+				windows, resp, err = env.Client.MaintenanceWindows.List(&MaintenanceWindowListOptions{
+					Filter:   MaintenanceWindowFilterOngoing,
+					Services: "PIJ90N7,PF9KMXH",
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the expected maintenance windows", func() {
+				Expect(windows).To(Equal([]MaintenanceWindow{expectedMaintenanceWindow}))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		Context("with a successful, non-empty response", func() {
+			var window *MaintenanceWindow
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/maintenance_windows/PFCVPS0", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, maintenanceWindowGetJSON),
+				))
+
+				window, resp, err = env.Client.MaintenanceWindows.Get("PFCVPS0")
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the expected maintenance window", func() {
+				Expect(window).To(Equal(&expectedMaintenanceWindow))
+			})
+		})
+	})
+
+	Describe("Create", func() {
+		var (
+			window *MaintenanceWindow
+			start  = time.Date(2015, time.November, 9, 20, 0, 0, 0, time.UTC)
+			end    = start.Add(2 * time.Hour)
+		)
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/maintenance_windows", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"maintenance_window": {
+							"start_time": "2015-11-09T20:00:00Z",
+							"end_time": "2015-11-09T22:00:00Z",
+							"description": "Immanentizing the eschaton",
+							"service_ids": ["PIJ90N7"]
+						},
+						"requester_id": "PT23IWX"
+					}`),
+					ghttp.RespondWith(http.StatusCreated, maintenanceWindowGetJSON),
+				))
+
+				window, resp, err = env.Client.MaintenanceWindows.Create(&MaintenanceWindow{
+					StartTime:   Time(start),
+					EndTime:     Time(end),
+					Description: String("Immanentizing the eschaton"),
+					ServiceIDs:  []string{"PIJ90N7"},
+				}, "PT23IWX")
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the created maintenance window", func() {
+				Expect(window).To(Equal(&expectedMaintenanceWindow))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/maintenance_windows", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"maintenance_window": {
+							"start_time": "2015-11-09T20:00:00Z",
+							"end_time": "2015-11-09T22:00:00Z",
+							"services": [{"id": "PIJ90N7", "type": "service_reference"}]
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, maintenanceWindowGetJSON),
+				))
+
+				window, resp, err = env.Client.MaintenanceWindows.Create(&MaintenanceWindow{
+					StartTime:  Time(start),
+					EndTime:    Time(end),
+					ServiceIDs: []string{"PIJ90N7"},
+				}, "")
+			})
+
+			It("should send the services as references", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		Context("with a successful, non-empty response", func() {
+			var window *MaintenanceWindow
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/maintenance_windows/PFCVPS0", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"maintenance_window": {
+							"id": "PFCVPS0",
+							"description": "Immanentizing the eschaton"
+						}
+					}`),
+					ghttp.RespondWith(http.StatusOK, maintenanceWindowGetJSON),
+				))
+
+				window, resp, err = env.Client.MaintenanceWindows.Update(&MaintenanceWindow{
+					ID:          String("PFCVPS0"),
+					Description: String("Immanentizing the eschaton"),
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the updated maintenance window", func() {
+				Expect(window).To(Equal(&expectedMaintenanceWindow))
+			})
+		})
+
+		Context("without an id", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.MaintenanceWindows.Update(&MaintenanceWindow{})
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		Context("with a successful response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(DELETE, "/maintenance_windows/PFCVPS0", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				))
+
+				resp, err = env.Client.MaintenanceWindows.Delete("PFCVPS0")
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		var (
+			called bool
+			fnErr  error
+		)
+
+		BeforeEach(func() {
+			called, fnErr = false, nil
+
+			end := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+			env.Server.RouteToHandler(POST, "/maintenance_windows", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusCreated, `{
+					"maintenance_window": { "id": "PFCVPS0", "end_time": "`+end+`" }
+				}`),
+			))
+			env.Server.RouteToHandler(DELETE, "/maintenance_windows/PFCVPS0", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		JustBeforeEach(func() {
+			err = env.Client.MaintenanceWindows.Run(context.Background(), &MaintenanceWindow{
+				EndTime:    Time(time.Now().Add(time.Hour)),
+				ServiceIDs: []string{"PIJ90N7"},
+			}, "PT23IWX", func(ctx context.Context) error {
+				called = true
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				return fnErr
+			})
+		})
+
+		Context("when the function succeeds", func() {
+			It("should run the function inside the window and end it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(called).To(BeTrue())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+				Expect(env.Server.ReceivedRequests()[1].Method).To(Equal(DELETE))
+			})
+		})
+
+		Context("when the function fails", func() {
+			BeforeEach(func() { fnErr = errors.New("deploy failed") })
+
+			It("should still end the window and return the error of the function", func() {
+				Expect(err).To(Equal(fnErr))
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when the window cannot be created", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/maintenance_windows", ghttp.RespondWith(http.StatusBadRequest, nil))
+			})
+
+			It("should not run the function", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(called).To(BeFalse())
+			})
+		})
+
+		Context("when the created window is missing from the response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/maintenance_windows", ghttp.RespondWith(http.StatusCreated, `{}`))
+			})
+
+			It("should return an error without running the function", func() {
+				Expect(err).To(MatchError("pagerduty: created maintenance window has no id"))
+				Expect(called).To(BeFalse())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,201 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	NotificationRuleUrgencyHigh = "high"
+	NotificationRuleUrgencyLow  = "low"
+	NotificationRuleUrgencyAny  = "any"
+
+	ObjectTypeAssignmentNotificationRule = "assignment_notification_rule"
+)
+
+// NotificationRule represents a rule notifying a user through one of their
+// contact methods once an incident has been assigned to them for a delay.
+type NotificationRule struct {
+	ID                  *string        `json:"id,omitempty"`
+	StartDelayInMinutes *int           `json:"start_delay_in_minutes,omitempty"`
+	ContactMethod       *ContactMethod `json:"contact_method,omitempty"`
+	Urgency             *string        `json:"urgency,omitempty"`
+
+	APIObject
+}
+
+// notificationRuleV1 is the body of the REST API v1 requests creating or
+// updating a notification rule, which take the id of the contact method
+// instead of an object.
+type notificationRuleV1 struct {
+	StartDelayInMinutes *int    `json:"start_delay_in_minutes,omitempty"`
+	ContactMethodID     *string `json:"contact_method_id,omitempty"`
+	Urgency             *string `json:"urgency,omitempty"`
+}
+
+type notificationRuleListWrapper struct {
+	NotificationRules []NotificationRule `json:"notification_rules"`
+}
+
+type notificationRuleWrapper struct {
+	NotificationRule *NotificationRule `json:"notification_rule"`
+}
+
+// notificationRuleBody returns the request body creating or updating rule.
+// The REST API v1 receives the rule unwrapped with the id of its contact
+// method, the REST API v2 a reference to the contact method, which requires
+// its type.
+func (s *UsersService) notificationRuleBody(rule *NotificationRule) (interface{}, error) {
+	if !s.client.isV2() {
+		body := &notificationRuleV1{
+			StartDelayInMinutes: rule.StartDelayInMinutes,
+			Urgency:             rule.Urgency,
+		}
+		if rule.ContactMethod != nil {
+			body.ContactMethodID = rule.ContactMethod.ID
+		}
+
+		return body, nil
+	}
+
+	r := *rule
+	if r.Type == nil {
+		r.Type = String(ObjectTypeAssignmentNotificationRule)
+	}
+
+	if m := r.ContactMethod; m != nil && m.ID != nil {
+		if m.Type == nil {
+			return nil, fmt.Errorf("pagerduty: notification rule contact method type cannot be nil")
+		}
+
+		t := *m.Type
+		if v2, ok := contactMethodTypesV2[t]; ok {
+			t = v2
+		}
+		if !strings.HasSuffix(t, "_reference") {
+			t += "_reference"
+		}
+
+		r.ContactMethod = &ContactMethod{ID: m.ID, APIObject: APIObject{Type: String(t)}}
+	}
+
+	return &notificationRuleWrapper{NotificationRule: &r}, nil
+}
+
+// ListNotificationRules fetches the notification rules of a user.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/notification_rules/list
+func (s *UsersService) ListNotificationRules(userID string) ([]NotificationRule, *Response, error) {
+	return s.ListNotificationRulesContext(context.Background(), userID)
+}
+
+// ListNotificationRulesContext is the context aware variant of
+// ListNotificationRules.
+func (s *UsersService) ListNotificationRulesContext(ctx context.Context, userID string) ([]NotificationRule, *Response, error) {
+	uri := fmt.Sprintf("users/%s/notification_rules", userID)
+
+	rules := new(notificationRuleListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, rules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rules.NotificationRules, resp, err
+}
+
+// GetNotificationRule fetches a notification rule of a user by id.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/notification_rules/show
+func (s *UsersService) GetNotificationRule(userID, id string) (*NotificationRule, *Response, error) {
+	return s.GetNotificationRuleContext(context.Background(), userID, id)
+}
+
+// GetNotificationRuleContext is the context aware variant of
+// GetNotificationRule.
+func (s *UsersService) GetNotificationRuleContext(ctx context.Context, userID, id string) (*NotificationRule, *Response, error) {
+	uri := fmt.Sprintf("users/%s/notification_rules/%s", userID, id)
+
+	rule := new(notificationRuleWrapper)
+	resp, err := s.client.GetContext(ctx, uri, rule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rule.NotificationRule, resp, err
+}
+
+// CreateNotificationRule adds a notification rule to a user, notifying them
+// through rule.ContactMethod, which only needs its ID set, or its ID and Type
+// for a client speaking the REST API v2.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/notification_rules/create
+func (s *UsersService) CreateNotificationRule(userID string, rule *NotificationRule) (*NotificationRule, *Response, error) {
+	return s.CreateNotificationRuleContext(context.Background(), userID, rule)
+}
+
+// CreateNotificationRuleContext is the context aware variant of
+// CreateNotificationRule.
+func (s *UsersService) CreateNotificationRuleContext(ctx context.Context, userID string, rule *NotificationRule) (*NotificationRule, *Response, error) {
+	if rule == nil {
+		return nil, nil, fmt.Errorf("pagerduty: notification rule cannot be nil")
+	}
+
+	body, err := s.notificationRuleBody(rule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uri := fmt.Sprintf("users/%s/notification_rules", userID)
+
+	r := new(notificationRuleWrapper)
+	resp, err := s.client.PostContext(ctx, uri, body, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r.NotificationRule, resp, err
+}
+
+// UpdateNotificationRule updates an existing notification rule of a user.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/notification_rules/update
+func (s *UsersService) UpdateNotificationRule(userID string, rule *NotificationRule) (*NotificationRule, *Response, error) {
+	return s.UpdateNotificationRuleContext(context.Background(), userID, rule)
+}
+
+// UpdateNotificationRuleContext is the context aware variant of
+// UpdateNotificationRule.
+func (s *UsersService) UpdateNotificationRuleContext(ctx context.Context, userID string, rule *NotificationRule) (*NotificationRule, *Response, error) {
+	if rule == nil || rule.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: notification rule id cannot be nil")
+	}
+
+	body, err := s.notificationRuleBody(rule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uri := fmt.Sprintf("users/%s/notification_rules/%s", userID, *rule.ID)
+
+	r := new(notificationRuleWrapper)
+	resp, err := s.client.PutContext(ctx, uri, body, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r.NotificationRule, resp, err
+}
+
+// DeleteNotificationRule removes a notification rule from a user.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/notification_rules/delete
+func (s *UsersService) DeleteNotificationRule(userID, id string) (*Response, error) {
+	return s.DeleteNotificationRuleContext(context.Background(), userID, id)
+}
+
+// DeleteNotificationRuleContext is the context aware variant of
+// DeleteNotificationRule.
+func (s *UsersService) DeleteNotificationRuleContext(ctx context.Context, userID, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("users/%s/notification_rules/%s", userID, id))
+}
@@ -0,0 +1,233 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	notificationRuleListJSON = `{ "notification_rules": [` + notificationRuleJSON + `]}`
+	notificationRuleGetJSON  = `{ "notification_rule": ` + notificationRuleJSON + `}`
+	notificationRuleJSON     = `{
+		"id": "PPSCXAN",
+		"start_delay_in_minutes": 0,
+		"urgency": "high",
+		"contact_method": ` + contactMethodJSON + `
+	}`
+)
+
+var _ = Describe("NotificationRules", func() {
+	var (
+		env                      *TestEnvironment
+		expectedNotificationRule NotificationRule
+	)
+
+	json.Unmarshal([]byte(notificationRuleJSON), &expectedNotificationRule)
+
+	BeforeEach(func() { env = NewTestEnvironment() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("ListNotificationRules", func() {
+		Context("with a successful, non-empty response", func() {
+			var (
+				rules []NotificationRule
+				resp  *Response
+				err   error
+			)
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/users/PXPGF42/notification_rules", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.RespondWith(http.StatusOK, notificationRuleListJSON),
+				))
+
+				rules, resp, err = env.Client.Users.ListNotificationRules("PXPGF42")
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the expected notification rules", func() {
+				Expect(rules).To(Equal([]NotificationRule{expectedNotificationRule}))
+				Expect(*rules[0].ContactMethod.ID).To(Equal("PDBIBUJ"))
+			})
+		})
+	})
+
+	Describe("GetNotificationRule", func() {
+		It("should return the expected notification rule", func() {
+			env.Server.RouteToHandler(GET, "/users/PXPGF42/notification_rules/PPSCXAN", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusOK, notificationRuleGetJSON),
+			))
+
+			rule, _, err := env.Client.Users.GetNotificationRule("PXPGF42", "PPSCXAN")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule).To(Equal(&expectedNotificationRule))
+		})
+	})
+
+	Describe("CreateNotificationRule", func() {
+		var (
+			rule *NotificationRule
+			resp *Response
+			err  error
+		)
+
+		newRule := func() *NotificationRule {
+			return &NotificationRule{
+				StartDelayInMinutes: Int(5),
+				Urgency:             String(NotificationRuleUrgencyHigh),
+				ContactMethod: &ContactMethod{
+					ID:        String("PDBIBUJ"),
+					APIObject: APIObject{Type: String(ContactMethodTypeEmail)},
+				},
+			}
+		}
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/users/PXPGF42/notification_rules", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"start_delay_in_minutes": 5,
+						"contact_method_id": "PDBIBUJ",
+						"urgency": "high"
+					}`),
+					ghttp.RespondWith(http.StatusCreated, notificationRuleGetJSON),
+				))
+
+				rule, resp, err = env.Client.Users.CreateNotificationRule("PXPGF42", newRule())
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the created notification rule", func() {
+				Expect(rule).To(Equal(&expectedNotificationRule))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/users/PXPGF42/notification_rules", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"notification_rule": {
+							"type": "assignment_notification_rule",
+							"start_delay_in_minutes": 5,
+							"urgency": "high",
+							"contact_method": {
+								"id": "PDBIBUJ",
+								"type": "email_contact_method_reference"
+							}
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, notificationRuleGetJSON),
+				))
+
+				rule, resp, err = env.Client.Users.CreateNotificationRule("PXPGF42", newRule())
+			})
+
+			It("should reference the contact method", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("with the REST API v2 and a contact method without a type", func() {
+			It("should return an error without making a request", func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				r := newRule()
+				r.ContactMethod.Type = nil
+
+				_, _, err = env.Client.Users.CreateNotificationRule("PXPGF42", r)
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		Context("without a notification rule", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Users.CreateNotificationRule("PXPGF42", nil)
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("UpdateNotificationRule", func() {
+		var err error
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/users/PXPGF42/notification_rules/PPSCXAN", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.VerifyJSON(`{ "start_delay_in_minutes": 10 }`),
+					ghttp.RespondWith(http.StatusOK, notificationRuleGetJSON),
+				))
+
+				_, _, err = env.Client.Users.UpdateNotificationRule("PXPGF42", &NotificationRule{
+					ID:                  String("PPSCXAN"),
+					StartDelayInMinutes: Int(10),
+				})
+			})
+
+			It("should update the notification rule", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("without an id", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Users.UpdateNotificationRule("PXPGF42", &NotificationRule{StartDelayInMinutes: Int(10)})
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteNotificationRule", func() {
+		It("should delete the notification rule", func() {
+			env.Server.RouteToHandler(DELETE, "/users/PXPGF42/notification_rules/PPSCXAN", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.Users.DeleteNotificationRule("PXPGF42", "PPSCXAN")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
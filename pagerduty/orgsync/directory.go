@@ -0,0 +1,187 @@
+package orgsync
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// Directory is a source of the users and team memberships an account should
+// have, such as an LDAP server or an HR export.
+type Directory interface {
+	Load(ctx context.Context) (*State, error)
+}
+
+// State is the desired set of users and teams of an account.
+type State struct {
+	Users []User `json:"users"`
+	Teams []Team `json:"teams,omitempty"`
+}
+
+// User is a user of the directory, identified by their email address. Empty
+// optional fields are left untouched in the account.
+type User struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+
+	// Optional account role, job title and IANA time zone of the user.
+	Role     string `json:"role,omitempty"`
+	JobTitle string `json:"job_title,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// Team is a team of the directory, identified by its name. Only the teams of
+// the directory are managed, and their members are exactly the ones listed.
+type Team struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Members     []Membership `json:"members,omitempty"`
+}
+
+// Membership is a user of a team, identified by their email address, with
+// their role in the team, which defaults to responder.
+type Membership struct {
+	Email string `json:"email"`
+	Role  string `json:"role,omitempty"`
+}
+
+// Load returns the state itself, so that a State can be used as a Directory.
+func (s *State) Load(ctx context.Context) (*State, error) {
+	return s, nil
+}
+
+// Validate checks that every user has an email address and a name, that no
+// email address or team name is used twice, and that the members of the teams
+// are users of the directory with a known role.
+func (s *State) Validate() error {
+	users := make(map[string]bool)
+	for _, u := range s.Users {
+		email := normalize(u.Email)
+		if email == "" || u.Name == "" {
+			return fmt.Errorf("orgsync: user %q must have an email address and a name", u.Email)
+		}
+		if users[email] {
+			return fmt.Errorf("orgsync: user %q is listed twice", u.Email)
+		}
+		users[email] = true
+	}
+
+	teams := make(map[string]bool)
+	for _, t := range s.Teams {
+		if t.Name == "" {
+			return fmt.Errorf("orgsync: teams must have a name")
+		}
+		if teams[t.Name] {
+			return fmt.Errorf("orgsync: team %q is listed twice", t.Name)
+		}
+		teams[t.Name] = true
+
+		for _, m := range t.Members {
+			if !users[normalize(m.Email)] {
+				return fmt.Errorf("orgsync: member %q of team %q is not a user of the directory", m.Email, t.Name)
+			}
+
+			switch m.Role {
+			case "", pagerduty.TeamRoleObserver, pagerduty.TeamRoleResponder, pagerduty.TeamRoleManager:
+			default:
+				return fmt.Errorf("orgsync: member %q of team %q has unknown role %q", m.Email, t.Name, m.Role)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReadJSON reads a state from its JSON representation.
+func ReadJSON(r io.Reader) (*State, error) {
+	state := new(State)
+	if err := json.NewDecoder(r).Decode(state); err != nil {
+		return nil, fmt.Errorf("orgsync: decoding directory: %w", err)
+	}
+
+	return state, nil
+}
+
+// ReadCSV reads a state from CSV with one user per row, after a header row
+// naming the columns among email, name, role, job_title, time_zone and teams.
+// The teams column lists the teams of the user separated by semicolons, each
+// optionally followed by a colon and the role of the user in the team, e.g.
+// "Platform:manager;Payments".
+func ReadCSV(r io.Reader) (*State, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("orgsync: reading directory header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, fmt.Errorf("orgsync: directory has no email column")
+	}
+
+	state := new(State)
+	teams := make(map[string]*Team)
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("orgsync: reading directory: %w", err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		user := User{
+			Email:    field("email"),
+			Name:     field("name"),
+			Role:     field("role"),
+			JobTitle: field("job_title"),
+			TimeZone: field("time_zone"),
+		}
+		state.Users = append(state.Users, user)
+
+		for _, entry := range strings.Split(field("teams"), ";") {
+			name, role, _ := strings.Cut(strings.TrimSpace(entry), ":")
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+
+			team, ok := teams[name]
+			if !ok {
+				team = &Team{Name: name}
+				teams[name] = team
+			}
+			team.Members = append(team.Members, Membership{Email: user.Email, Role: strings.TrimSpace(role)})
+		}
+	}
+
+	for _, team := range teams {
+		state.Teams = append(state.Teams, *team)
+	}
+	sort.Slice(state.Teams, func(i, j int) bool { return state.Teams[i].Name < state.Teams[j].Name })
+
+	return state, nil
+}
+
+// normalize returns the key identifying the user with the given email
+// address.
+func normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
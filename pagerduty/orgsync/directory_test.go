@@ -0,0 +1,112 @@
+package orgsync_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty/orgsync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+)
+
+var _ = Describe("Directory", func() {
+	Describe("reading CSV", func() {
+		var (
+			state *State
+			err   error
+		)
+
+		BeforeEach(func() {
+			state, err = ReadCSV(strings.NewReader(`email,name,job_title,teams
+alice@example.com,Alice,Engineer,Platform
+bob@example.com, Robert ,,Platform:manager; Payments
+carol@example.com,Carol,,
+`))
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return the users", func() {
+			Expect(state.Users).To(Equal([]User{
+				{Email: "alice@example.com", Name: "Alice", JobTitle: "Engineer"},
+				{Email: "bob@example.com", Name: "Robert"},
+				{Email: "carol@example.com", Name: "Carol"},
+			}))
+		})
+
+		It("should return the teams sorted by name", func() {
+			Expect(state.Teams).To(Equal([]Team{
+				{Name: "Payments", Members: []Membership{{Email: "bob@example.com"}}},
+				{Name: "Platform", Members: []Membership{
+					{Email: "alice@example.com"},
+					{Email: "bob@example.com", Role: "manager"},
+				}},
+			}))
+		})
+
+		It("should return a valid state", func() {
+			Expect(state.Validate()).To(Succeed())
+		})
+
+		It("should require an email column", func() {
+			_, err := ReadCSV(strings.NewReader("name\nAlice\n"))
+			Expect(err).To(MatchError("orgsync: directory has no email column"))
+		})
+	})
+
+	Describe("reading JSON", func() {
+		It("should return the state", func() {
+			state, err := ReadJSON(strings.NewReader(`{
+				"users": [{"email": "alice@example.com", "name": "Alice", "time_zone": "Europe/Paris"}],
+				"teams": [{"name": "Platform", "members": [{"email": "alice@example.com", "role": "observer"}]}]
+			}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state).To(Equal(&State{
+				Users: []User{{Email: "alice@example.com", Name: "Alice", TimeZone: "Europe/Paris"}},
+				Teams: []Team{{Name: "Platform", Members: []Membership{{Email: "alice@example.com", Role: "observer"}}}},
+			}))
+		})
+	})
+
+	Describe("validating", func() {
+		var state *State
+
+		BeforeEach(func() {
+			state = &State{
+				Users: []User{{Email: "alice@example.com", Name: "Alice"}},
+				Teams: []Team{{Name: "Platform", Members: []Membership{{Email: "alice@example.com"}}}},
+			}
+		})
+
+		It("should accept a valid state", func() {
+			Expect(state.Validate()).To(Succeed())
+		})
+
+		It("should reject users without a name", func() {
+			state.Users = append(state.Users, User{Email: "bob@example.com"})
+			Expect(state.Validate()).To(MatchError(`orgsync: user "bob@example.com" must have an email address and a name`))
+		})
+
+		It("should reject users listed twice", func() {
+			state.Users = append(state.Users, User{Email: "Alice@Example.com", Name: "Alice"})
+			Expect(state.Validate()).To(MatchError(`orgsync: user "Alice@Example.com" is listed twice`))
+		})
+
+		It("should reject teams listed twice", func() {
+			state.Teams = append(state.Teams, Team{Name: "Platform"})
+			Expect(state.Validate()).To(MatchError(`orgsync: team "Platform" is listed twice`))
+		})
+
+		It("should reject members missing from the directory", func() {
+			state.Teams[0].Members = append(state.Teams[0].Members, Membership{Email: "bob@example.com"})
+			Expect(state.Validate()).To(MatchError(`orgsync: member "bob@example.com" of team "Platform" is not a user of the directory`))
+		})
+
+		It("should reject unknown roles", func() {
+			state.Teams[0].Members[0].Role = "owner"
+			Expect(state.Validate()).To(MatchError(`orgsync: member "alice@example.com" of team "Platform" has unknown role "owner"`))
+		})
+	})
+})
@@ -0,0 +1,525 @@
+// Package orgsync reconciles the users and teams of a PagerDuty account with
+// a directory, such as an LDAP server or an HR export.
+//
+// A Syncer compares the state loaded from a Directory with the account and
+// plans the operations making the account match it: creating the missing
+// teams and users, updating the users whose details changed, adding, updating
+// and removing team memberships, and optionally deactivating the users missing
+// from the directory. Deactivations are refused for an empty directory, and
+// can be capped with MaxDeactivations. Plans can be printed for a dry run
+// before being applied.
+//
+// Team roles only exist in the REST API v2, so the client must speak it.
+package orgsync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// Action is the kind of an operation of a plan.
+type Action string
+
+const (
+	ActionCreateTeam     Action = "create_team"
+	ActionCreateUser     Action = "create_user"
+	ActionUpdateUser     Action = "update_user"
+	ActionAddMember      Action = "add_member"
+	ActionUpdateMember   Action = "update_member"
+	ActionRemoveMember   Action = "remove_member"
+	ActionDeactivateUser Action = "deactivate_user"
+)
+
+// Change is the change of a field of a user.
+type Change struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Operation is a change to apply to the account.
+type Operation struct {
+	Action Action `json:"action"`
+
+	// The email address of the user and the name of the team the operation
+	// is about, if any.
+	Email string `json:"email,omitempty"`
+	Team  string `json:"team,omitempty"`
+
+	// The role of the user in the team, for membership operations, and their
+	// previous role, for role updates.
+	Role     string `json:"role,omitempty"`
+	FromRole string `json:"from_role,omitempty"`
+
+	// The changes of the user, for user updates.
+	Changes []Change `json:"changes,omitempty"`
+
+	user *User
+	team *Team
+}
+
+// signs prefix the description of the operations adding something to the
+// account with '+', changing something with '~' and removing something with
+// '-'.
+var signs = map[Action]string{
+	ActionCreateTeam:     "+",
+	ActionCreateUser:     "+",
+	ActionUpdateUser:     "~",
+	ActionAddMember:      "+",
+	ActionUpdateMember:   "~",
+	ActionRemoveMember:   "-",
+	ActionDeactivateUser: "-",
+}
+
+// String describes the operation on a line, prefixed with '+' when it adds
+// something to the account, '~' when it changes something and '-' when it
+// removes something.
+func (o Operation) String() string {
+	return signs[o.Action] + " " + o.describe()
+}
+
+func (o Operation) describe() string {
+	switch o.Action {
+	case ActionCreateTeam:
+		return fmt.Sprintf("create team %q", o.Team)
+	case ActionCreateUser:
+		return fmt.Sprintf("create user %s (%s)", o.Email, o.user.Name)
+	case ActionUpdateUser:
+		var changes []string
+		for _, c := range o.Changes {
+			changes = append(changes, fmt.Sprintf("%s %q -> %q", c.Field, c.From, c.To))
+		}
+		return fmt.Sprintf("update user %s: %s", o.Email, strings.Join(changes, ", "))
+	case ActionAddMember:
+		return fmt.Sprintf("add %s to team %q as %s", o.Email, o.Team, o.Role)
+	case ActionUpdateMember:
+		return fmt.Sprintf("change role of %s in team %q: %s -> %s", o.Email, o.Team, o.FromRole, o.Role)
+	case ActionRemoveMember:
+		return fmt.Sprintf("remove %s from team %q", o.Email, o.Team)
+	case ActionDeactivateUser:
+		return fmt.Sprintf("deactivate user %s", o.Email)
+	}
+
+	return string(o.Action)
+}
+
+// Plan is the list of operations making an account match a directory, in the
+// order they are applied.
+type Plan struct {
+	Operations []Operation `json:"operations"`
+
+	// the ids of the users and teams of the account, by email address and
+	// name, completed with the ids of the created ones while applying
+	userIDs map[string]string
+	teamIDs map[string]string
+}
+
+// Empty reports whether the account already matches the directory.
+func (p *Plan) Empty() bool {
+	return len(p.Operations) == 0
+}
+
+// WriteTo writes the operations of the plan to w, one per line, for a dry
+// run.
+func (p *Plan) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	if p.Empty() {
+		n, err := fmt.Fprintln(w, "no changes")
+		return int64(n), err
+	}
+
+	for _, op := range p.Operations {
+		n, err := fmt.Fprintln(w, op)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// Syncer reconciles an account with a directory.
+type Syncer struct {
+	client *pagerduty.Client
+
+	// Whether the users of the account missing from the directory are
+	// deactivated. PagerDuty has no deactivated state, so they are removed
+	// from the account. Defaults to false, leaving them untouched.
+	Deactivate bool
+
+	// The email addresses of the users never deactivated, such as service
+	// accounts.
+	Protected []string
+
+	// The maximum number of users a plan may deactivate, guarding against
+	// truncated directories. Planning more deactivations fails. Defaults to
+	// zero, for no limit.
+	MaxDeactivations int
+}
+
+// NewSyncer returns a Syncer reconciling the account of client, which must
+// speak the REST API v2.
+func NewSyncer(client *pagerduty.Client) *Syncer {
+	return &Syncer{client: client}
+}
+
+// account is the state of the users and managed teams of the account.
+type account struct {
+	users map[string]pagerduty.User // by email address
+	teams map[string]pagerduty.Team // by name
+
+	// the roles of the members of the managed teams, by team name and email
+	// address
+	members map[string]map[string]string
+}
+
+func (s *Syncer) load(ctx context.Context, state *State) (*account, error) {
+	a := &account{
+		users:   make(map[string]pagerduty.User),
+		teams:   make(map[string]pagerduty.Team),
+		members: make(map[string]map[string]string),
+	}
+
+	users, err := s.client.Users.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("orgsync: listing users: %w", err)
+	}
+
+	emails := make(map[string]string)
+	for _, u := range users {
+		if u.Email == nil || u.ID == nil {
+			continue
+		}
+		a.users[normalize(*u.Email)] = u
+		emails[*u.ID] = normalize(*u.Email)
+	}
+
+	teams, err := s.client.Teams.ListAllContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("orgsync: listing teams: %w", err)
+	}
+
+	managed := make(map[string]bool)
+	for _, t := range state.Teams {
+		managed[t.Name] = true
+	}
+
+	for _, t := range teams {
+		if t.Name == nil || t.ID == nil || !managed[*t.Name] {
+			continue
+		}
+		a.teams[*t.Name] = t
+
+		members, err := s.client.Teams.ListAllMembersContext(ctx, *t.ID, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("orgsync: listing members of team %q: %w", *t.Name, err)
+		}
+
+		roles := make(map[string]string)
+		for _, m := range members {
+			if m.User == nil || m.User.ID == nil {
+				continue
+			}
+
+			email, ok := emails[*m.User.ID]
+			if !ok {
+				continue
+			}
+			roles[email] = pagerduty.TeamRoleResponder
+			if m.Role != nil {
+				roles[email] = *m.Role
+			}
+		}
+		a.members[*t.Name] = roles
+	}
+
+	return a, nil
+}
+
+// Plan loads the directory and the account, and returns the operations making
+// the account match the directory.
+func (s *Syncer) Plan(ctx context.Context, dir Directory) (*Plan, error) {
+	if s.client.APIVersion() != pagerduty.APIVersion2 {
+		return nil, fmt.Errorf("orgsync: syncing requires a client speaking the REST API v2")
+	}
+
+	state, err := dir.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("orgsync: loading directory: %w", err)
+	}
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+	if s.Deactivate && len(state.Users) == 0 {
+		// most likely a failed export, which would empty the account
+		return nil, fmt.Errorf("orgsync: refusing to deactivate the users of an empty directory")
+	}
+
+	a, err := s.load(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{userIDs: make(map[string]string), teamIDs: make(map[string]string)}
+	for email, u := range a.users {
+		p.userIDs[email] = *u.ID
+	}
+	for name, t := range a.teams {
+		p.teamIDs[name] = *t.ID
+	}
+
+	teams := append([]Team(nil), state.Teams...)
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	for i := range teams {
+		if _, ok := a.teams[teams[i].Name]; !ok {
+			p.Operations = append(p.Operations, Operation{Action: ActionCreateTeam, Team: teams[i].Name, team: &teams[i]})
+		}
+	}
+
+	users := append([]User(nil), state.Users...)
+	sort.Slice(users, func(i, j int) bool { return normalize(users[i].Email) < normalize(users[j].Email) })
+
+	var updates []Operation
+	directory := make(map[string]bool)
+	for i := range users {
+		u := &users[i]
+		email := normalize(u.Email)
+		directory[email] = true
+
+		current, ok := a.users[email]
+		if !ok {
+			p.Operations = append(p.Operations, Operation{Action: ActionCreateUser, Email: email, user: u})
+			continue
+		}
+
+		if changes := userChanges(&current, u); len(changes) > 0 {
+			updates = append(updates, Operation{Action: ActionUpdateUser, Email: email, Changes: changes, user: u})
+		}
+	}
+	p.Operations = append(p.Operations, updates...)
+
+	for _, t := range teams {
+		current := a.members[t.Name]
+		desired := make(map[string]bool)
+
+		for _, m := range t.Members {
+			email := normalize(m.Email)
+			desired[email] = true
+
+			role := m.Role
+			if role == "" {
+				role = pagerduty.TeamRoleResponder
+			}
+
+			switch from, ok := current[email]; {
+			case !ok:
+				p.Operations = append(p.Operations, Operation{Action: ActionAddMember, Email: email, Team: t.Name, Role: role})
+			case from != role:
+				p.Operations = append(p.Operations, Operation{Action: ActionUpdateMember, Email: email, Team: t.Name, Role: role, FromRole: from})
+			}
+		}
+
+		var removed []string
+		for email := range current {
+			if !desired[email] {
+				removed = append(removed, email)
+			}
+		}
+		sort.Strings(removed)
+		for _, email := range removed {
+			p.Operations = append(p.Operations, Operation{Action: ActionRemoveMember, Email: email, Team: t.Name})
+		}
+	}
+
+	if s.Deactivate {
+		protected := make(map[string]bool)
+		for _, email := range s.Protected {
+			protected[normalize(email)] = true
+		}
+
+		var missing []string
+		for email := range a.users {
+			if !directory[email] && !protected[email] {
+				missing = append(missing, email)
+			}
+		}
+		if s.MaxDeactivations > 0 && len(missing) > s.MaxDeactivations {
+			return nil, fmt.Errorf("orgsync: refusing to deactivate %d users, more than the maximum of %d", len(missing), s.MaxDeactivations)
+		}
+
+		sort.Strings(missing)
+		for _, email := range missing {
+			p.Operations = append(p.Operations, Operation{Action: ActionDeactivateUser, Email: email})
+		}
+	}
+
+	return p, nil
+}
+
+// userChanges returns the changes making current match the optional fields of
+// desired that are set.
+func userChanges(current *pagerduty.User, desired *User) []Change {
+	var changes []Change
+	compare := func(field string, from *string, to string) {
+		if to == "" {
+			return
+		}
+
+		var f string
+		if from != nil {
+			f = *from
+		}
+		if f != to {
+			changes = append(changes, Change{Field: field, From: f, To: to})
+		}
+	}
+
+	compare("name", current.Name, desired.Name)
+	compare("role", current.Role, desired.Role)
+	compare("job_title", current.JobTitle, desired.JobTitle)
+
+	var tz *string
+	if current.TimeZone != nil && current.TimeZone.Location != nil {
+		tz = pagerduty.String(current.TimeZone.Location.String())
+	}
+	compare("time_zone", tz, desired.TimeZone)
+
+	return changes
+}
+
+// Apply applies the operations of the plan in order, writing each of them to
+// out, when it is not nil, once applied. It stops at the first failing
+// operation.
+func (s *Syncer) Apply(ctx context.Context, p *Plan, out io.Writer) error {
+	for _, op := range p.Operations {
+		if err := s.apply(ctx, p, &op); err != nil {
+			return fmt.Errorf("orgsync: %s: %w", op.describe(), err)
+		}
+
+		if out != nil {
+			fmt.Fprintln(out, op)
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) apply(ctx context.Context, p *Plan, op *Operation) error {
+	switch op.Action {
+	case ActionCreateTeam:
+		team := &pagerduty.Team{Name: pagerduty.String(op.team.Name)}
+		if op.team.Description != "" {
+			team.Description = pagerduty.String(op.team.Description)
+		}
+
+		created, _, err := s.client.Teams.CreateContext(ctx, team)
+		if err != nil {
+			return err
+		}
+		if created == nil || created.ID == nil {
+			return fmt.Errorf("created team has no id")
+		}
+		p.teamIDs[op.Team] = *created.ID
+
+	case ActionCreateUser, ActionUpdateUser:
+		user, err := pagerDutyUser(op)
+		if err != nil {
+			return err
+		}
+
+		if op.Action == ActionUpdateUser {
+			user.ID = pagerduty.String(p.userIDs[op.Email])
+			_, _, err = s.client.Users.UpdateContext(ctx, user)
+			return err
+		}
+
+		created, _, err := s.client.Users.CreateContext(ctx, user)
+		if err != nil {
+			return err
+		}
+		if created == nil || created.ID == nil {
+			return fmt.Errorf("created user has no id")
+		}
+		p.userIDs[op.Email] = *created.ID
+
+	case ActionAddMember, ActionUpdateMember:
+		_, err := s.client.Teams.AddUserContext(ctx, p.teamIDs[op.Team], p.userIDs[op.Email], op.Role)
+		return err
+
+	case ActionRemoveMember:
+		_, err := s.client.Teams.RemoveUserContext(ctx, p.teamIDs[op.Team], p.userIDs[op.Email])
+		return err
+
+	case ActionDeactivateUser:
+		_, err := s.client.Users.DeleteContext(ctx, p.userIDs[op.Email])
+		return err
+
+	default:
+		return fmt.Errorf("unknown action %q", op.Action)
+	}
+
+	return nil
+}
+
+// pagerDutyUser returns the user created or updated by op. Updates only send
+// the changed fields.
+func pagerDutyUser(op *Operation) (*pagerduty.User, error) {
+	fields := map[string]string{
+		"name":      op.user.Name,
+		"role":      op.user.Role,
+		"job_title": op.user.JobTitle,
+		"time_zone": op.user.TimeZone,
+	}
+	if op.Action == ActionUpdateUser {
+		fields = make(map[string]string)
+		for _, c := range op.Changes {
+			fields[c.Field] = c.To
+		}
+	}
+
+	user := &pagerduty.User{}
+	if op.Action == ActionCreateUser {
+		user.Email = pagerduty.String(op.user.Email)
+	}
+	if v := fields["name"]; v != "" {
+		user.Name = pagerduty.String(v)
+	}
+	if v := fields["role"]; v != "" {
+		user.Role = pagerduty.String(v)
+	}
+	if v := fields["job_title"]; v != "" {
+		user.JobTitle = pagerduty.String(v)
+	}
+	if v := fields["time_zone"]; v != "" {
+		loc, err := time.LoadLocation(v)
+		if err != nil {
+			return nil, fmt.Errorf("time zone %q does not exist", v)
+		}
+		user.TimeZone = &pagerduty.TimeZone{Location: loc}
+	}
+
+	return user, nil
+}
+
+// Sync plans the operations making the account match the directory, writes
+// them to out and, unless dryRun is set, applies them.
+func (s *Syncer) Sync(ctx context.Context, dir Directory, dryRun bool, out io.Writer) (*Plan, error) {
+	p, err := s.Plan(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		_, err = p.WriteTo(out)
+		return p, err
+	}
+
+	return p, s.Apply(ctx, p, out)
+}
@@ -0,0 +1,13 @@
+package orgsync_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestOrgsync(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Orgsync Suite")
+}
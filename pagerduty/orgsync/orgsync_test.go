@@ -0,0 +1,285 @@
+package orgsync_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/orgsync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const (
+	usersJSON = `{"more": false, "users": [
+		{"id": "PALICE1", "name": "Alice", "email": "alice@example.com", "role": "user"},
+		{"id": "PBOB001", "name": "Bob", "email": "Bob@Example.com", "role": "user"},
+		{"id": "PDAVE01", "name": "Dave", "email": "dave@example.com", "role": "user"},
+		{"id": "PSVC001", "name": "Service", "email": "svc@example.com", "role": "admin"}
+	]}`
+	teamsJSON = `{"more": false, "teams": [
+		{"id": "PTEAM01", "name": "Platform"},
+		{"id": "POTHER1", "name": "Other"}
+	]}`
+	membersJSON = `{"more": false, "members": [
+		{"user": {"id": "PALICE1"}, "role": "responder"},
+		{"user": {"id": "PBOB001"}, "role": "observer"},
+		{"user": {"id": "PDAVE01"}, "role": "responder"}
+	]}`
+)
+
+var _ = Describe("Syncer", func() {
+	var (
+		server *ghttp.Server
+		client *pagerduty.Client
+		syncer *Syncer
+		state  *State
+
+		plan *Plan
+		err  error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = pagerduty.NewClientV2(nil, "super-secret-key", "")
+		client.BaseURL, _ = url.Parse(server.URL())
+
+		server.RouteToHandler("GET", "/users", ghttp.RespondWith(http.StatusOK, usersJSON))
+		server.RouteToHandler("GET", "/teams", ghttp.RespondWith(http.StatusOK, teamsJSON))
+		server.RouteToHandler("GET", "/teams/PTEAM01/members", ghttp.RespondWith(http.StatusOK, membersJSON))
+
+		syncer = NewSyncer(client)
+		syncer.Deactivate = true
+		syncer.Protected = []string{"SVC@example.com"}
+
+		state = &State{
+			Users: []User{
+				{Email: "alice@example.com", Name: "Alice"},
+				{Email: "bob@example.com", Name: "Robert"},
+				{Email: "carol@example.com", Name: "Carol", JobTitle: "Engineer"},
+			},
+			Teams: []Team{
+				{Name: "Platform", Members: []Membership{
+					{Email: "alice@example.com"},
+					{Email: "bob@example.com", Role: "responder"},
+				}},
+				{Name: "Payments", Description: "Payment processing", Members: []Membership{
+					{Email: "bob@example.com", Role: "manager"},
+					{Email: "carol@example.com"},
+				}},
+			},
+		}
+	})
+
+	AfterEach(func() { server.Close() })
+
+	Describe("planning", func() {
+		JustBeforeEach(func() {
+			plan, err = syncer.Plan(context.Background(), state)
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only list the members of the managed teams", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("should return the operations in order", func() {
+			var buf bytes.Buffer
+			_, err := plan.WriteTo(&buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal(`+ create team "Payments"
++ create user carol@example.com (Carol)
+~ update user bob@example.com: name "Bob" -> "Robert"
++ add bob@example.com to team "Payments" as manager
++ add carol@example.com to team "Payments" as responder
+~ change role of bob@example.com in team "Platform": observer -> responder
+- remove dave@example.com from team "Platform"
+- deactivate user dave@example.com
+`))
+		})
+
+		It("should describe the changes of the users", func() {
+			Expect(plan.Operations[2].Changes).To(Equal([]Change{{Field: "name", From: "Bob", To: "Robert"}}))
+		})
+
+		Context("without deactivation", func() {
+			BeforeEach(func() { syncer.Deactivate = false })
+
+			It("should leave the missing users untouched", func() {
+				Expect(plan.Operations).To(HaveLen(7))
+				for _, op := range plan.Operations {
+					Expect(op.Action).NotTo(Equal(ActionDeactivateUser))
+				}
+			})
+		})
+
+		Context("with an empty directory", func() {
+			BeforeEach(func() { state = &State{} })
+
+			It("should refuse to deactivate every user before reading the account", func() {
+				Expect(err).To(MatchError("orgsync: refusing to deactivate the users of an empty directory"))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+
+			Context("without deactivation", func() {
+				BeforeEach(func() { syncer.Deactivate = false })
+
+				It("should not return an error", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Context("with more deactivations than the maximum", func() {
+			BeforeEach(func() {
+				syncer.Protected = nil
+				syncer.MaxDeactivations = 1
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError("orgsync: refusing to deactivate 2 users, more than the maximum of 1"))
+				Expect(plan).To(BeNil())
+			})
+		})
+
+		Context("with as many deactivations as the maximum", func() {
+			BeforeEach(func() { syncer.MaxDeactivations = 1 })
+
+			It("should plan them", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Operations[len(plan.Operations)-1].Action).To(Equal(ActionDeactivateUser))
+			})
+		})
+
+		Context("when the account matches the directory", func() {
+			BeforeEach(func() {
+				syncer.Deactivate = false
+				state = &State{
+					Users: []User{{Email: "alice@example.com", Name: "Alice"}},
+					Teams: []Team{{Name: "Platform", Members: []Membership{
+						{Email: "alice@example.com"},
+						{Email: "bob@example.com", Role: "observer"},
+						{Email: "dave@example.com"},
+					}}},
+				}
+				state.Users = append(state.Users,
+					User{Email: "bob@example.com", Name: "Bob"},
+					User{Email: "dave@example.com", Name: "Dave"},
+				)
+			})
+
+			It("should return an empty plan", func() {
+				Expect(plan.Empty()).To(BeTrue())
+
+				var buf bytes.Buffer
+				plan.WriteTo(&buf)
+				Expect(buf.String()).To(Equal("no changes\n"))
+			})
+		})
+
+		Context("with an invalid directory", func() {
+			BeforeEach(func() { state.Users = append(state.Users, User{Email: "erin@example.com"}) })
+
+			It("should return an error before reading the account", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		Context("with a client speaking the REST API v1", func() {
+			BeforeEach(func() { syncer = NewSyncer(pagerduty.NewClient(nil, "subdomain", "super-secret-key")) })
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError("orgsync: syncing requires a client speaking the REST API v2"))
+			})
+		})
+	})
+
+	Describe("applying", func() {
+		var out bytes.Buffer
+
+		BeforeEach(func() {
+			out.Reset()
+
+			server.RouteToHandler("POST", "/teams", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"team": {"name": "Payments", "description": "Payment processing"}}`),
+				ghttp.RespondWith(http.StatusCreated, `{"team": {"id": "PTEAM02", "name": "Payments"}}`),
+			))
+			server.RouteToHandler("POST", "/users", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"user": {"name": "Carol", "email": "carol@example.com", "job_title": "Engineer", "type": "user"}}`),
+				ghttp.RespondWith(http.StatusCreated, `{"user": {"id": "PCAROL1", "name": "Carol"}}`),
+			))
+			server.RouteToHandler("PUT", "/users/PBOB001", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"user": {"id": "PBOB001", "name": "Robert", "type": "user"}}`),
+				ghttp.RespondWith(http.StatusOK, `{"user": {"id": "PBOB001", "name": "Robert"}}`),
+			))
+			server.RouteToHandler("PUT", "/teams/PTEAM02/users/PBOB001", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"role": "manager"}`),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+			server.RouteToHandler("PUT", "/teams/PTEAM02/users/PCAROL1", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"role": "responder"}`),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+			server.RouteToHandler("PUT", "/teams/PTEAM01/users/PBOB001", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"role": "responder"}`),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+			server.RouteToHandler("DELETE", "/teams/PTEAM01/users/PDAVE01", ghttp.RespondWith(http.StatusNoContent, nil))
+			server.RouteToHandler("DELETE", "/users/PDAVE01", ghttp.RespondWith(http.StatusNoContent, nil))
+
+			plan, err = syncer.Plan(context.Background(), state)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			err = syncer.Apply(context.Background(), plan, &out)
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should have made the requests of every operation", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(3 + 8))
+		})
+
+		It("should write the applied operations", func() {
+			Expect(out.String()).To(ContainSubstring(`+ create team "Payments"`))
+			Expect(out.String()).To(HaveSuffix("- deactivate user dave@example.com\n"))
+		})
+
+		Context("when an operation fails", func() {
+			BeforeEach(func() {
+				server.RouteToHandler("POST", "/users", ghttp.RespondWith(http.StatusBadRequest, `{"error": {"message": "Invalid Input Provided"}}`))
+			})
+
+			It("should return the error of the operation", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("orgsync: create user carol@example.com (Carol): "))
+			})
+
+			It("should stop at the failing operation", func() {
+				Expect(server.ReceivedRequests()).To(HaveLen(3 + 2))
+			})
+		})
+	})
+
+	Describe("syncing in dry run", func() {
+		It("should write the plan without applying it", func() {
+			var out bytes.Buffer
+			plan, err := syncer.Sync(context.Background(), state, true, &out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Operations).To(HaveLen(8))
+			Expect(out.String()).To(HavePrefix(`+ create team "Payments"`))
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+	})
+})
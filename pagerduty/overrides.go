@@ -0,0 +1,177 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Override represents a schedule override, putting a user on call in place of
+// whoever the schedule layers put on call between Start and End.
+type Override struct {
+	ID    *string    `json:"id,omitempty"`
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+	User  *User      `json:"user,omitempty"`
+}
+
+// overrideV1 is the body of the REST API v1 requests creating an override,
+// which take the id of the user instead of an object.
+type overrideV1 struct {
+	Start  *time.Time `json:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+	UserID *string    `json:"user_id,omitempty"`
+}
+
+type ScheduleOverridesOptions struct {
+	// The start of the date range over which you want to search.
+	Since time.Time `url:"since,omitempty"`
+
+	// The end of the date range over which you want to search.
+	Until time.Time `url:"until,omitempty"`
+
+	// When true, only returns editable overrides, i.e. the ones that have not
+	// ended yet.
+	Editable bool `url:"editable,omitempty"`
+
+	// Whether overrides crossing the range are returned whole rather than
+	// truncated to the range.
+	Overflow bool `url:"overflow,omitempty"`
+}
+
+type overrideListWrapper struct {
+	Overrides []Override `json:"overrides"`
+}
+
+type overrideWrapper struct {
+	Override interface{} `json:"override"`
+}
+
+// ListOverrides lists the overrides of a schedule over a date range.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/overrides/list
+func (s *SchedulesService) ListOverrides(scheduleID string, opts *ScheduleOverridesOptions) ([]Override, *Response, error) {
+	return s.ListOverridesContext(context.Background(), scheduleID, opts)
+}
+
+// ListOverridesContext is the context aware variant of ListOverrides.
+func (s *SchedulesService) ListOverridesContext(ctx context.Context, scheduleID string, opts *ScheduleOverridesOptions) ([]Override, *Response, error) {
+	path := fmt.Sprintf("schedules/%s/overrides", scheduleID)
+	uri, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	overrides := new(overrideListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, overrides)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return overrides.Overrides, resp, err
+}
+
+// CreateOverride creates an override on a schedule for override.User, which
+// only needs its ID set.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/overrides/create
+func (s *SchedulesService) CreateOverride(scheduleID string, override *Override) (*Override, *Response, error) {
+	return s.CreateOverrideContext(context.Background(), scheduleID, override)
+}
+
+// CreateOverrideContext is the context aware variant of CreateOverride.
+func (s *SchedulesService) CreateOverrideContext(ctx context.Context, scheduleID string, override *Override) (*Override, *Response, error) {
+	if override == nil || override.User == nil || override.User.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: override user id cannot be nil")
+	}
+
+	uri := fmt.Sprintf("schedules/%s/overrides", scheduleID)
+
+	var body interface{}
+	if s.client.isV2() {
+		o := *override
+		o.User = &User{ID: override.User.ID, APIObject: APIObject{Type: String(ObjectTypeUserReference)}}
+		body = &o
+	} else {
+		body = &overrideV1{Start: override.Start, End: override.End, UserID: override.User.ID}
+	}
+
+	o := new(Override)
+	resp, err := s.client.PostContext(ctx, uri, &overrideWrapper{Override: body}, &overrideWrapper{Override: o})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return o, resp, err
+}
+
+// DeleteOverride deletes an override of a schedule, or ends it if it is
+// ongoing.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/overrides/delete
+func (s *SchedulesService) DeleteOverride(scheduleID, id string) (*Response, error) {
+	return s.DeleteOverrideContext(context.Background(), scheduleID, id)
+}
+
+// DeleteOverrideContext is the context aware variant of DeleteOverride.
+func (s *SchedulesService) DeleteOverrideContext(ctx context.Context, scheduleID, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("schedules/%s/overrides/%s", scheduleID, id))
+}
+
+// TakeShift puts userID on call in place of fromUserID on a schedule between
+// since and until. It creates one override for every span of the rendered
+// schedule during which fromUserID is on call, truncated to the range, and
+// returns them.
+//
+// If creating an override fails, the overrides created so far are returned
+// along with the error.
+func (s *SchedulesService) TakeShift(scheduleID, userID, fromUserID string, since, until time.Time) ([]Override, error) {
+	return s.TakeShiftContext(context.Background(), scheduleID, userID, fromUserID, since, until)
+}
+
+// TakeShiftContext is the context aware variant of TakeShift.
+func (s *SchedulesService) TakeShiftContext(ctx context.Context, scheduleID, userID, fromUserID string, since, until time.Time) ([]Override, error) {
+	if !since.Before(until) {
+		return nil, fmt.Errorf("pagerduty: shift must end after it starts")
+	}
+
+	entries, _, err := s.EntriesContext(ctx, scheduleID, &ScheduleEntriesOptions{
+		Since:  since,
+		Until:  until,
+		UserID: fromUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []Override
+	for _, e := range entries {
+		if e.Start == nil || e.End == nil {
+			continue
+		}
+
+		start, end := *e.Start, *e.End
+		if start.Before(since) {
+			start = since
+		}
+		if end.After(until) {
+			end = until
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		o, _, err := s.CreateOverrideContext(ctx, scheduleID, &Override{
+			Start: Time(start),
+			End:   Time(end),
+			User:  &User{ID: String(userID)},
+		})
+		if err != nil {
+			return overrides, err
+		}
+
+		overrides = append(overrides, *o)
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,251 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	overrideListJSON = `{ "overrides": [` + overrideJSON + `]}`
+	overrideGetJSON  = `{ "override": ` + overrideJSON + `}`
+	overrideJSON     = `{
+		"id": "PQ47DCP",
+		"start": "2012-12-07T17:00:00-08:00",
+		"end": "2012-12-07T20:00:00-08:00",
+		"user": {
+			"id": "PEYSGVF",
+			"name": "Wiley Jacobson"
+		}
+	}`
+	overrideEntriesJSON = `{ "entries": [
+		{
+			"start": "2012-12-06T20:00:00Z",
+			"end": "2012-12-07T20:00:00Z",
+			"user": { "id": "PT23IWX" }
+		},
+		{
+			"start": "2012-12-08T20:00:00Z",
+			"end": "2012-12-09T20:00:00Z",
+			"user": { "id": "PT23IWX" }
+		}
+	]}`
+)
+
+var _ = Describe("Overrides", func() {
+	var (
+		env              *TestEnvironment
+		expectedOverride Override
+
+		override *Override
+		resp     *Response
+		err      error
+	)
+
+	json.Unmarshal([]byte(overrideJSON), &expectedOverride)
+
+	BeforeEach(func() { env = NewTestEnvironment() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("ListOverrides", func() {
+		Context("with a successful, non-empty response", func() {
+			var overrides []Override
+
+			BeforeEach(func() {
+				values := make(url.Values)
+				values.Add("since", testTimeString)
+				values.Add("until", testTimeString)
+				values.Add("editable", "true")
+
+				env.Server.RouteToHandler(GET, "/schedules/PI7DH85/overrides", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyURLQueryHandler(values),
+					ghttp.RespondWith(http.StatusOK, overrideListJSON),
+				))
+
+				overrides, resp, err = env.Client.Schedules.ListOverrides("PI7DH85", &ScheduleOverridesOptions{
+					Since:    testTime,
+					Until:    testTime,
+					Editable: true,
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the expected overrides", func() {
+				Expect(overrides).To(Equal([]Override{expectedOverride}))
+			})
+		})
+	})
+
+	Describe("CreateOverride", func() {
+		var (
+			start = time.Date(2012, time.December, 8, 1, 0, 0, 0, time.UTC)
+			end   = start.Add(3 * time.Hour)
+		)
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/schedules/PI7DH85/overrides", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"override": {
+							"start": "2012-12-08T01:00:00Z",
+							"end": "2012-12-08T04:00:00Z",
+							"user_id": "PEYSGVF"
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, overrideGetJSON),
+				))
+
+				override, resp, err = env.Client.Schedules.CreateOverride("PI7DH85", &Override{
+					Start: Time(start),
+					End:   Time(end),
+					User:  &User{ID: String("PEYSGVF")},
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the created override", func() {
+				Expect(override).To(Equal(&expectedOverride))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/schedules/PI7DH85/overrides", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"override": {
+							"start": "2012-12-08T01:00:00Z",
+							"end": "2012-12-08T04:00:00Z",
+							"user": { "id": "PEYSGVF", "type": "user_reference" }
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, overrideGetJSON),
+				))
+
+				override, resp, err = env.Client.Schedules.CreateOverride("PI7DH85", &Override{
+					Start: Time(start),
+					End:   Time(end),
+					User:  &User{ID: String("PEYSGVF"), Name: String("Wiley Jacobson")},
+				})
+			})
+
+			It("should send the user as a reference", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(override).To(Equal(&expectedOverride))
+			})
+		})
+
+		Context("without a user", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Schedules.CreateOverride("PI7DH85", &Override{})
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteOverride", func() {
+		It("should delete the override", func() {
+			env.Server.RouteToHandler(DELETE, "/schedules/PI7DH85/overrides/PQ47DCP", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err = env.Client.Schedules.DeleteOverride("PI7DH85", "PQ47DCP")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("TakeShift", func() {
+		var (
+			since = time.Date(2012, time.December, 7, 0, 0, 0, 0, time.UTC)
+			until = time.Date(2012, time.December, 9, 0, 0, 0, 0, time.UTC)
+
+			overrides []Override
+			bodies    []string
+		)
+
+		BeforeEach(func() {
+			bodies = nil
+
+			env.Server.RouteToHandler(GET, "/schedules/PI7DH85/entries", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				verifyURLQueryHandler(url.Values{
+					"since":   []string{"2012-12-07T00:00:00Z"},
+					"until":   []string{"2012-12-09T00:00:00Z"},
+					"user_id": []string{"PT23IWX"},
+				}),
+				ghttp.RespondWith(http.StatusOK, overrideEntriesJSON),
+			))
+			env.Server.RouteToHandler(POST, "/schedules/PI7DH85/overrides", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				func(w http.ResponseWriter, r *http.Request) {
+					body, _ := ioutil.ReadAll(r.Body)
+					bodies = append(bodies, string(body))
+				},
+				ghttp.RespondWith(http.StatusCreated, overrideGetJSON),
+			))
+
+			overrides, err = env.Client.Schedules.TakeShift("PI7DH85", "PEYSGVF", "PT23IWX", since, until)
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should create an override for every shift of the other user", func() {
+			Expect(overrides).To(HaveLen(2))
+			Expect(bodies).To(HaveLen(2))
+		})
+
+		It("should truncate the overrides to the requested range", func() {
+			Expect(bodies[0]).To(MatchJSON(`{
+				"override": {
+					"start": "2012-12-07T00:00:00Z",
+					"end": "2012-12-07T20:00:00Z",
+					"user_id": "PEYSGVF"
+				}
+			}`))
+			Expect(bodies[1]).To(MatchJSON(`{
+				"override": {
+					"start": "2012-12-08T20:00:00Z",
+					"end": "2012-12-09T00:00:00Z",
+					"user_id": "PEYSGVF"
+				}
+			}`))
+		})
+	})
+})
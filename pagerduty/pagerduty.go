@@ -7,13 +7,16 @@ package pagerduty
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -21,16 +24,32 @@ import (
 
 const (
 	defaultBaseURL   = "https://%s.pagerduty.com/api/v1/"
+	defaultBaseURLV2 = "https://api.pagerduty.com/"
 	defaultEventsURL = "https://events.pagerduty.com/"
 
 	headerAuthorization = "Authorization"
 	headerAccept        = "Accept"
 	headerContentType   = "Content-Type"
+	headerFrom          = "From"
 
 	authorizationToken = "Token token=%s"
 	acceptType         = "application/json"
+	acceptTypeV2       = "application/vnd.pagerduty+json;version=2"
 	contentType        = "application/json"
 
+	// versions of the PagerDuty REST API
+	APIVersion1 = 1
+	APIVersion2 = 2
+
+	// types of the REST API v2 reference objects
+	ObjectTypeEscalationPolicyReference = "escalation_policy_reference"
+	ObjectTypeIncidentReference         = "incident_reference"
+	ObjectTypeScheduleReference         = "schedule_reference"
+	ObjectTypeServiceReference          = "service_reference"
+	ObjectTypeTeamReference             = "team_reference"
+	ObjectTypeUserReference             = "user_reference"
+	ObjectTypeVendorReference           = "vendor_reference"
+
 	// http verb constants
 	DELETE = "DELETE"
 	GET    = "GET"
@@ -55,11 +74,24 @@ type Client struct {
 	// PagerDuty API key.
 	APIKey string
 
+	// Email address of the user making requests. It is sent in the 'From'
+	// header, which the REST API v2 requires for most write operations.
+	From string
+
+	// Version of the REST API the client speaks.
+	apiVersion int
+
+	// Policy used to retry rate limited and failed requests. Requests are not
+	// retried when nil.
+	RetryPolicy *RetryPolicy
+
 	// Services used for talking to different parts of the PagerDuty API.
 	Alerts             *AlertsService
 	EscalationPolicies *EscalationPoliciesService
 	Events             *EventsService
 	Incidents          *IncidentsService
+	LogEntries         *LogEntriesService
+	MaintenanceWindows *MaintenanceWindowsService
 	Schedules          *SchedulesService
 	Services           *ServicesService
 	Teams              *TeamsService
@@ -78,28 +110,71 @@ func NewClient(httpClient *http.Client, subdomain string, apiKey ...string) *Cli
 	baseURL, _ := url.Parse(renderedURL)
 	eventsURL, _ := url.Parse(defaultEventsURL)
 	c := &Client{
-		client:    httpClient,
-		BaseURL:   baseURL,
-		EventsURL: eventsURL,
-		subdomain: subdomain,
+		client:     httpClient,
+		BaseURL:    baseURL,
+		EventsURL:  eventsURL,
+		subdomain:  subdomain,
+		apiVersion: APIVersion1,
 	}
 
 	if len(apiKey) == 1 {
 		c.APIKey = apiKey[0]
 	}
 
-	// configure services
+	c.configureServices()
+
+	return c
+}
+
+// NewClientV2 returns a new PagerDuty client for the REST API v2, which is
+// served from api.pagerduty.com and therefore needs no subdomain. from is the
+// email address of the user making the requests. If httpClient is nil,
+// http.DefaultClient will be used.
+func NewClientV2(httpClient *http.Client, apiKey, from string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	baseURL, _ := url.Parse(defaultBaseURLV2)
+	eventsURL, _ := url.Parse(defaultEventsURL)
+	c := &Client{
+		client:     httpClient,
+		BaseURL:    baseURL,
+		EventsURL:  eventsURL,
+		APIKey:     apiKey,
+		From:       from,
+		apiVersion: APIVersion2,
+	}
+
+	c.configureServices()
+
+	return c
+}
+
+// APIVersion returns the version of the REST API the client speaks.
+func (c *Client) APIVersion() int {
+	return c.apiVersion
+}
+
+// isV2 reports whether the client speaks the REST API v2.
+func (c *Client) isV2() bool {
+	return c.apiVersion == APIVersion2
+}
+
+// configureServices registers the services used for talking to the different
+// parts of the PagerDuty API.
+func (c *Client) configureServices() {
 	c.Alerts = &AlertsService{client: c}
 	c.EscalationPolicies = &EscalationPoliciesService{client: c}
 	c.Events = &EventsService{client: c}
 	c.Incidents = &IncidentsService{client: c}
+	c.LogEntries = &LogEntriesService{client: c}
+	c.MaintenanceWindows = &MaintenanceWindowsService{client: c}
 	c.Schedules = &SchedulesService{client: c}
 	c.Services = &ServicesService{client: c}
 	c.Teams = &TeamsService{client: c}
 	c.Users = &UsersService{client: c}
 	c.Webhooks = &WebhooksService{client: c}
-
-	return c
 }
 
 type ListOptions struct {
@@ -108,6 +183,10 @@ type ListOptions struct {
 
 	// The number of records returned. Default (and max limit) is 100 for most APIs.
 	Limit int `url:"limit,omitempty"`
+
+	// Request the total number of records, which the REST API v2 omits by
+	// default for performance reasons. Ignored by the REST API v1.
+	Total bool `url:"total,omitempty"`
 }
 
 // addOptions adds the parameters in opt as URL query parameters to s.
@@ -135,9 +214,17 @@ func addOptions(s string, opt interface{}) (string, error) {
 	return u.String(), nil
 }
 
-// newRequest is a helper function to generate an http.Request and automagically
-// json encode a body and resolve the given path.
-func newRequest(baseURL *url.URL, method, path string, body interface{}) (*http.Request, error) {
+// errNilContext is returned when a nil context is passed to one of the context
+// aware methods.
+var errNilContext = errors.New("pagerduty: context must be non-nil")
+
+// newRequest is a helper function to generate an http.Request bound to ctx and
+// automagically json encode a body and resolve the given path.
+func newRequest(ctx context.Context, baseURL *url.URL, method, path string, body interface{}) (*http.Request, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
 	rel, err := url.Parse(path)
 	if err != nil {
 		return nil, err
@@ -154,7 +241,7 @@ func newRequest(baseURL *url.URL, method, path string, body interface{}) (*http.
 		}
 	}
 
-	req, err := http.NewRequest(method, uri.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, uri.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -171,13 +258,34 @@ func newRequest(baseURL *url.URL, method, path string, body interface{}) (*http.
 // specified, the value pointed to by body is JSON encoded and included as the
 // request body
 func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
-	req, err := newRequest(c.BaseURL, method, path, body)
+	return c.NewRequestContext(context.Background(), method, path, body)
+}
+
+// NewRequestContext creates an API request bound to ctx. It has the same
+// requirements and behaviors as Client.NewRequest.
+func (c *Client) NewRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	if c.isV2() {
+		var err error
+		path, body, err = ianaTimeZones(path, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := newRequest(ctx, c.BaseURL, method, path, body)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Add(headerAuthorization, fmt.Sprintf(authorizationToken, c.APIKey))
 
+	if c.isV2() {
+		req.Header.Set(headerAccept, acceptTypeV2)
+		if c.From != "" {
+			req.Header.Set(headerFrom, c.From)
+		}
+	}
+
 	return req, nil
 }
 
@@ -190,16 +298,77 @@ type Response struct {
 	// Pagination response fields. Any or all of these may be set to the zero
 	// zero value for responses that are not part of a paginated set.
 
-	Offset int `json:"offset,omitempty"` // The offset used in the execution of the query
-	Limit  int `json:"limit,omitempty"`  // The limit used in the execution of the query
-	Total  int `json:"total,omitemtpy"`  // The total number of records available
+	Offset int   `json:"offset,omitempty"` // The offset used in the execution of the query
+	Limit  int   `json:"limit,omitempty"`  // The limit used in the execution of the query
+	Total  int   `json:"total,omitempty"`  // The total number of records available
+	More   *bool `json:"more,omitempty"`   // Whether more records are available (REST API v2 only)
+}
+
+// APIObject holds the fields that the REST API v2 returns for every object,
+// including the reference objects used to link objects to each other.
+type APIObject struct {
+	Type    *string `json:"type,omitempty"`
+	Summary *string `json:"summary,omitempty"`
+	Self    *string `json:"self,omitempty"`
+	HTMLURL *string `json:"html_url,omitempty"`
+}
+
+// Reference is a REST API v2 reference object, used to link an object to
+// another one by id.
+type Reference struct {
+	ID   *string `json:"id,omitempty"`
+	Type *string `json:"type,omitempty"`
+}
+
+// NewReference returns a reference of the given type (e.g. 'user_reference')
+// to the object with the given id.
+func NewReference(refType, id string) *Reference {
+	return &Reference{ID: String(id), Type: String(refType)}
+}
+
+// splitIDs splits a comma-separated list of ids, as used by the REST API v1
+// filters.
+func splitIDs(ids string) []string {
+	if ids == "" {
+		return nil
+	}
+
+	var r []string
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			r = append(r, id)
+		}
+	}
+
+	return r
+}
+
+// errUnsupportedV2 returns the error reported when a method has no equivalent
+// in the REST API v2.
+func errUnsupportedV2(method string) error {
+	return fmt.Errorf("pagerduty: %s is not supported by the REST API v2", method)
+}
+
+// errRequiresV2 returns the error reported when a method only exists in the
+// REST API v2.
+func errRequiresV2(method string) error {
+	return fmt.Errorf("pagerduty: %s requires a client speaking the REST API v2", method)
 }
 
 // Do sends an API request and returns the API response. The API response is
 // JSON decoded and stored in the value pointed to by v, or returned as an
-// error if an API error has occurred.
+// error if an API error has occurred. The request is bound to the context
+// already attached to req.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
+	return c.DoContext(req.Context(), req, v)
+}
+
+// DoContext sends an API request bound to ctx and returns the API response.
+// It has the same behavior as Client.Do, except that the request is aborted
+// and ctx.Err() is returned when ctx is canceled or its deadline is exceeded.
+// Failed requests are retried according to the client's RetryPolicy.
+func (c *Client) DoContext(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	resp, err := c.send(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -236,42 +405,66 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 
 // Delete is a convenience function to create and execute a DELETE request.
 func (c *Client) Delete(path string) (*Response, error) {
+	return c.DeleteContext(context.Background(), path)
+}
+
+// DeleteContext is a convenience function to create and execute a DELETE
+// request bound to ctx.
+func (c *Client) DeleteContext(ctx context.Context, path string) (*Response, error) {
 	req, err := c.NewRequest(DELETE, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.Do(req, nil)
+	return c.DoContext(ctx, req, nil)
 }
 
 // Get is a convenience function to create and execute a GET request.
 func (c *Client) Get(path string, v interface{}) (*Response, error) {
+	return c.GetContext(context.Background(), path, v)
+}
+
+// GetContext is a convenience function to create and execute a GET request
+// bound to ctx.
+func (c *Client) GetContext(ctx context.Context, path string, v interface{}) (*Response, error) {
 	req, err := c.NewRequest(GET, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.Do(req, v)
+	return c.DoContext(ctx, req, v)
 }
 
 // Post is a convenience function to create and execute a POST request.
 func (c *Client) Post(path string, body, v interface{}) (*Response, error) {
+	return c.PostContext(context.Background(), path, body, v)
+}
+
+// PostContext is a convenience function to create and execute a POST request
+// bound to ctx.
+func (c *Client) PostContext(ctx context.Context, path string, body, v interface{}) (*Response, error) {
 	req, err := c.NewRequest(POST, path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.Do(req, v)
+	return c.DoContext(ctx, req, v)
 }
 
 // Put is a convenience function to create and execute a PUT request.
 func (c *Client) Put(path string, body, v interface{}) (*Response, error) {
+	return c.PutContext(context.Background(), path, body, v)
+}
+
+// PutContext is a convenience function to create and execute a PUT request
+// bound to ctx.
+func (c *Client) PutContext(ctx context.Context, path string, body, v interface{}) (*Response, error) {
 	req, err := c.NewRequest(PUT, path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.Do(req, v)
+	return c.DoContext(ctx, req, v)
 }
 
 // An ErrorResponse reports an error caused by an API request.
@@ -22,6 +22,9 @@ const (
 
 	// The test api key.
 	apiKey = "super-secret-key"
+
+	// The test email address of the user making REST API v2 requests.
+	from = "user@example.com"
 )
 
 var (
@@ -58,6 +61,22 @@ func NewTestEnvironment() *TestEnvironment {
 	}
 }
 
+// NewTestEnvironmentV2 creates and configures a new test environment with a
+// PagerDuty client speaking the REST API v2.
+func NewTestEnvironmentV2() *TestEnvironment {
+	server := ghttp.NewServer()
+
+	client := NewClientV2(nil, apiKey, from)
+	url, _ := url.Parse(server.URL())
+	client.BaseURL = url
+	client.EventsURL = url
+
+	return &TestEnvironment{
+		Server: server,
+		Client: client,
+	}
+}
+
 // verifyContentHeaderHandler is an http.HandlerFunc that verifies that a
 // request has the proper values for the 'Accept' and 'Content-Type' headers.
 var verifyContentHeaderHandler = ghttp.CombineHandlers(
@@ -81,6 +100,17 @@ var verifyHeaderHandler = ghttp.CombineHandlers(
 	verifyAuthorizationHeaderHandler,
 )
 
+// verifyV2HeaderHandler is an http.HandlerFunc that verifies for the proper
+// headers in a request to the PagerDuty REST API v2.
+var verifyV2HeaderHandler = ghttp.CombineHandlers(
+	ghttp.VerifyHeader(http.Header{
+		"Accept": []string{"application/vnd.pagerduty+json;version=2"},
+		"From":   []string{from},
+	}),
+	ghttp.VerifyContentType("application/json"),
+	verifyAuthorizationHeaderHandler,
+)
+
 // verifyURLQueryHandler is an http.HandlerFunc that verifies that the values
 // of the request URL Query values is equal to provided values.
 func verifyURLQueryHandler(values url.Values) http.HandlerFunc {
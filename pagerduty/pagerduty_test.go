@@ -7,11 +7,13 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 var _ = Describe("PagerDuty", func() {
@@ -36,6 +38,8 @@ var _ = Describe("PagerDuty", func() {
 				Expect(client.EscalationPolicies).NotTo(BeNil())
 				Expect(client.Events).NotTo(BeNil())
 				Expect(client.Incidents).NotTo(BeNil())
+				Expect(client.LogEntries).NotTo(BeNil())
+				Expect(client.MaintenanceWindows).NotTo(BeNil())
 				Expect(client.Schedules).NotTo(BeNil())
 				Expect(client.Services).NotTo(BeNil())
 				Expect(client.Teams).NotTo(BeNil())
@@ -45,6 +49,41 @@ var _ = Describe("PagerDuty", func() {
 		})
 	})
 
+	Describe("Creating a new REST API v2 client", func() {
+		var client *Client
+
+		BeforeEach(func() {
+			client = NewClientV2(nil, apiKey, from)
+		})
+
+		It("should use the REST API v2 base URL", func() {
+			Expect(client.BaseURL.String()).To(Equal("https://api.pagerduty.com/"))
+		})
+
+		It("should speak the REST API v2", func() {
+			Expect(client.APIVersion()).To(Equal(APIVersion2))
+		})
+
+		It("should have the correct api key and requester", func() {
+			Expect(client.APIKey).To(Equal(apiKey))
+			Expect(client.From).To(Equal(from))
+		})
+
+		It("should register all services correctly", func() {
+			Expect(client.Incidents).NotTo(BeNil())
+			Expect(client.Webhooks).NotTo(BeNil())
+		})
+
+		It("should set the REST API v2 headers on requests", func() {
+			req, err := client.NewRequest(GET, "test", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(req.Header.Get("Accept")).To(Equal("application/vnd.pagerduty+json;version=2"))
+			Expect(req.Header.Get("From")).To(Equal(from))
+			Expect(req.Header.Get("Authorization")).To(Equal("Token token=" + apiKey))
+			Expect(req.URL.String()).To(Equal("https://api.pagerduty.com/test"))
+		})
+	})
+
 	Describe("Creating a new request", func() {
 		var (
 			client *Client
@@ -91,7 +130,7 @@ var _ = Describe("PagerDuty", func() {
 
 		Context("with invalid JSON", func() {
 			// test type with an unsupported json type
-			type T struct{ A map[int]interface{} }
+			type T struct{ A chan int }
 
 			It("should return a JSON unsupported type error", func() {
 				_, err = client.NewRequest(GET, "/", &T{})
@@ -187,6 +226,91 @@ var _ = Describe("PagerDuty", func() {
 		})
 	})
 
+	Describe("Performing a request with a context", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+			env    *TestEnvironment
+			err    error
+			resp   *Response
+		)
+
+		BeforeEach(func() {
+			env = NewTestEnvironment()
+
+			env.Server.RouteToHandler(GET, "/", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(50 * time.Millisecond)
+				},
+				ghttp.RespondWith(http.StatusOK, `{ "total": 1 }`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			req, _ := env.Client.NewRequest(GET, "/", nil)
+			resp, err = env.Client.DoContext(ctx, req, nil)
+		})
+
+		AfterEach(func() {
+			cancel()
+			env.Server.Close()
+		})
+
+		Context("that is still active", func() {
+			BeforeEach(func() {
+				ctx, cancel = context.WithCancel(context.Background())
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should parse and return the correct response body", func() {
+				Expect(resp).NotTo(BeNil())
+				Expect(resp.Total).To(Equal(1))
+			})
+		})
+
+		Context("that has already been canceled", func() {
+			BeforeEach(func() {
+				ctx, cancel = context.WithCancel(context.Background())
+				cancel()
+			})
+
+			It("should not have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+
+			It("should return the context's error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+
+		Context("whose deadline is exceeded before the response arrives", func() {
+			BeforeEach(func() {
+				ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+			})
+
+			It("should return the context's error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(context.DeadlineExceeded))
+			})
+		})
+
+		Context("that is nil", func() {
+			BeforeEach(func() {
+				ctx, cancel = nil, func() {}
+			})
+
+			It("should return an error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("Checking a response", func() {
 		var (
 			body string
@@ -0,0 +1,233 @@
+package pagerduty
+
+import (
+	"context"
+	"sync"
+)
+
+// PaginationOptions control how the ListAll methods walk paginated results.
+type PaginationOptions struct {
+	// The maximum number of records to return. Zero means all records.
+	Max int
+
+	// The maximum number of pages fetched concurrently once the total number
+	// of records is known. Values less than 2 fetch pages sequentially.
+	Concurrency int
+}
+
+// pageFetcher fetches the page of records described by opts.
+type pageFetcher[T any] func(ctx context.Context, opts ListOptions) ([]T, *Response, error)
+
+// Iterator lazily walks every record of a paginated list, fetching the next
+// page only when the records of the current one are exhausted.
+//
+//	it := client.Incidents.Iter(nil)
+//	for it.Next() {
+//		incident := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch pageFetcher[T]
+	opts  ListOptions
+
+	page []T
+	cur  T
+	resp *Response
+	done bool
+	err  error
+}
+
+func newIterator[T any](ctx context.Context, fetch pageFetcher[T], opts ListOptions) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, opts: opts}
+}
+
+// Next advances the iterator to the next record, which is then available
+// through Value. It returns false when there are no more records or an error
+// occurred.
+func (it *Iterator[T]) Next() bool {
+	if len(it.page) == 0 && !it.done && it.err == nil {
+		it.fetchPage()
+	}
+
+	if len(it.page) == 0 {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current record.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the first error encountered while fetching pages.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the response of the last fetched page.
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
+
+func (it *Iterator[T]) fetchPage() {
+	page, resp, err := it.fetch(it.ctx, it.opts)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = page
+	it.resp = resp
+	it.opts.Offset += len(page)
+	it.done = !hasMore(resp, it.opts, len(page))
+}
+
+// hasMore reports whether there are records left after a page of n records
+// was fetched. next holds the options of the page that would follow.
+func hasMore(resp *Response, next ListOptions, n int) bool {
+	if n == 0 || resp == nil {
+		return false
+	}
+
+	if resp.More != nil {
+		return *resp.More
+	}
+
+	if resp.Total > 0 {
+		return next.Offset < resp.Total
+	}
+
+	// the total is unknown, assume a short page is the last one
+	limit := next.Limit
+	if limit == 0 {
+		limit = resp.Limit
+	}
+
+	return limit == 0 || n >= limit
+}
+
+// listAll collects every record of a paginated list, starting at the page
+// described by start.
+func listAll[T any](ctx context.Context, fetch pageFetcher[T], start ListOptions, p *PaginationOptions) ([]T, error) {
+	if p == nil {
+		p = new(PaginationOptions)
+	}
+
+	// the first page is always fetched sequentially to learn the total
+	it := newIterator(ctx, fetch, start)
+	if !it.Next() {
+		return nil, it.Err()
+	}
+
+	all := append([]T{it.Value()}, it.page...)
+	it.page = nil
+	if p.Max > 0 && len(all) >= p.Max {
+		return all[:p.Max], nil
+	}
+
+	if p.Concurrency > 1 && !it.done && it.resp.Total > 0 {
+		end := it.resp.Total
+		if p.Max > 0 && start.Offset+p.Max < end {
+			end = start.Offset + p.Max
+		}
+
+		pageSize := it.resp.Limit
+		if pageSize == 0 {
+			pageSize = len(all)
+		}
+
+		rest, err := fetchConcurrently(ctx, fetch, it.opts.Offset, end, pageSize, p.Concurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, rest...)
+		if p.Max > 0 && len(all) > p.Max {
+			all = all[:p.Max]
+		}
+
+		return all, nil
+	}
+
+	for it.Next() {
+		all = append(all, it.Value())
+		if p.Max > 0 && len(all) >= p.Max {
+			break
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// fetchConcurrently fetches the records in [offset, end) using pages of
+// pageSize records and at most concurrency requests in flight. The records
+// are returned in order.
+func fetchConcurrently[T any](ctx context.Context, fetch pageFetcher[T], offset, end, pageSize, concurrency int) ([]T, error) {
+	var offsets []int
+	for ; offset < end; offset += pageSize {
+		offsets = append(offsets, offset)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		pages    = make([][]T, len(offsets))
+		sem      = make(chan struct{}, concurrency)
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	for i, offset := range offsets {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, opts ListOptions) {
+			defer func() { wg.Done(); <-sem }()
+
+			page, _, err := fetch(ctx, opts)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+
+			pages[i] = page
+		}(i, ListOptions{Offset: offset, Limit: pageSize})
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	// the caller's context may have been canceled before all pages were
+	// scheduled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var all []T
+	for _, page := range pages {
+		all = append(all, page...)
+	}
+
+	return all, nil
+}
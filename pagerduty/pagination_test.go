@@ -0,0 +1,213 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// paginatedTeamsHandler is an http.HandlerFunc that serves total teams in
+// pages of at most limit records, honoring the 'offset' and 'limit' query
+// parameters of the request.
+func paginatedTeamsHandler(total, limit int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit := limit
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+			limit = l
+		}
+
+		var teams []string
+		for i := offset; i < offset+limit && i < total; i++ {
+			teams = append(teams, fmt.Sprintf(`{ "id": "%d" }`, i))
+		}
+
+		fmt.Fprintf(w, `{ "teams": [%s], "offset": %d, "limit": %d, "total": %d }`,
+			strings.Join(teams, ","), offset, limit, total)
+	}
+}
+
+func teamIDs(teams []Team) []string {
+	ids := make([]string, len(teams))
+	for i, team := range teams {
+		ids[i] = *team.ID
+	}
+	return ids
+}
+
+var _ = Describe("Pagination", func() {
+	var (
+		env *TestEnvironment
+		err error
+	)
+
+	BeforeEach(func() { env = NewTestEnvironment() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("ListAll", func() {
+		var teams []Team
+
+		Context("with several pages of records", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/teams", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					paginatedTeamsHandler(5, 2),
+				))
+
+				teams, err = env.Client.Teams.ListAll(nil, nil)
+			})
+
+			It("should request every page", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(3))
+			})
+
+			It("should return every record in order", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(teamIDs(teams)).To(Equal([]string{"0", "1", "2", "3", "4"}))
+			})
+		})
+
+		Context("with a maximum number of records", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/teams", paginatedTeamsHandler(10, 2))
+
+				teams, err = env.Client.Teams.ListAll(nil, &PaginationOptions{Max: 3})
+			})
+
+			It("should stop once enough records are collected", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(teamIDs(teams)).To(Equal([]string{"0", "1", "2"}))
+			})
+		})
+
+		Context("with a starting offset and page size", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/teams", paginatedTeamsHandler(6, 100))
+
+				teams, err = env.Client.Teams.ListAll(&TeamListOptions{
+					ListOptions: ListOptions{Offset: 1, Limit: 2},
+				}, nil)
+			})
+
+			It("should start at the requested offset", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(3))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(teamIDs(teams)).To(Equal([]string{"1", "2", "3", "4", "5"}))
+			})
+		})
+
+		Context("with concurrent page fetching", func() {
+			var inFlight, maxInFlight int32
+
+			BeforeEach(func() {
+				inFlight, maxInFlight = 0, 0
+				env.Server.RouteToHandler(GET, "/teams", ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						n := atomic.AddInt32(&inFlight, 1)
+						for {
+							max := atomic.LoadInt32(&maxInFlight)
+							if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+								break
+							}
+						}
+						time.Sleep(10 * time.Millisecond)
+						atomic.AddInt32(&inFlight, -1)
+					},
+					paginatedTeamsHandler(9, 1),
+				))
+
+				teams, err = env.Client.Teams.ListAll(nil, &PaginationOptions{Concurrency: 3})
+			})
+
+			It("should request every page", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(9))
+			})
+
+			It("should bound the number of requests in flight", func() {
+				Expect(maxInFlight).To(BeNumerically("<=", 3))
+			})
+
+			It("should return every record in order", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(teamIDs(teams)).To(Equal([]string{"0", "1", "2", "3", "4", "5", "6", "7", "8"}))
+			})
+		})
+
+		Context("when a page fails", func() {
+			BeforeEach(func() {
+				env.Server.AppendHandlers(
+					paginatedTeamsHandler(4, 2),
+					ghttp.RespondWith(http.StatusInternalServerError, nil),
+				)
+
+				teams, err = env.Client.Teams.ListAll(nil, nil)
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(teams).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Iter", func() {
+		Context("with several pages of records", func() {
+			var ids []string
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/teams", paginatedTeamsHandler(3, 2))
+
+				ids = nil
+				it := env.Client.Teams.Iter(nil)
+				for it.Next() {
+					ids = append(ids, *it.Value().ID)
+				}
+				err = it.Err()
+			})
+
+			It("should walk every record in order", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(Equal([]string{"0", "1", "2"}))
+			})
+		})
+
+		Context("when it is not fully consumed", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/teams", paginatedTeamsHandler(10, 2))
+
+				it := env.Client.Teams.Iter(nil)
+				it.Next()
+				it.Next()
+			})
+
+			It("should only fetch the pages that were needed", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when a page fails", func() {
+			var it *Iterator[Team]
+
+			BeforeEach(func() {
+				env.Server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, nil))
+
+				it = env.Client.Teams.Iter(nil)
+			})
+
+			It("should stop and report the error", func() {
+				Expect(it.Next()).To(BeFalse())
+				Expect(it.Err()).To(HaveOccurred())
+			})
+		})
+	})
+})
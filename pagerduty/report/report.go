@@ -0,0 +1,448 @@
+// Package report computes how the on-call burden of a PagerDuty account is
+// distributed among its users over a date range: the hours each user spent on
+// call, the part of them outside their working hours, the incidents they
+// handled and the notifications they received.
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hudl/go-pagerduty/pagerduty"
+)
+
+// Options configure a load report.
+type Options struct {
+	// The date range of the report.
+	Since time.Time
+	Until time.Time
+
+	// The schedules whose on-call time is counted.
+	ScheduleIDs []string
+
+	// The working hours of the users, read in their time zone. Time on call
+	// outside them counts as off-hours. Default to 09:00 to 17:00, Monday to
+	// Friday.
+	WorkdayStart pagerduty.TimeOfDay
+	WorkdayEnd   pagerduty.TimeOfDay
+	Workdays     []time.Weekday
+
+	// Time zone of the users who have none, which defaults to UTC.
+	TimeZone *time.Location
+}
+
+// UserLoad is the on-call load of a user.
+type UserLoad struct {
+	UserID   string `json:"user_id"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	TimeZone string `json:"time_zone"`
+
+	// Hours spent on call, in total and outside working hours.
+	OnCallHours   float64 `json:"on_call_hours"`
+	OffHoursHours float64 `json:"off_hours_hours"`
+
+	// Share of the on-call hours of all users, in percent.
+	OnCallShare float64 `json:"on_call_share"`
+
+	// Incidents the user acknowledged, resolved or was assigned.
+	Incidents int `json:"incidents"`
+
+	// Notifications sent to the user, in total and outside working hours.
+	Interruptions         int `json:"interruptions"`
+	OffHoursInterruptions int `json:"off_hours_interruptions"`
+}
+
+// Report is the on-call load of the users over a date range.
+type Report struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+
+	// The users, by decreasing on-call hours.
+	Users []UserLoad `json:"users"`
+}
+
+// Generate fetches the on-call entries of the schedules, and the incidents
+// and alerts of the date range, and computes the load of every user
+// appearing in them. As the REST API v2 has no alerts, the notifications are
+// read from the notify log entries for clients speaking it.
+func Generate(ctx context.Context, client *pagerduty.Client, opts *Options) (*Report, error) {
+	if opts == nil || !opts.Since.Before(opts.Until) {
+		return nil, fmt.Errorf("report: date range must end after it starts")
+	}
+
+	b := newBuilder(opts)
+
+	for _, id := range opts.ScheduleIDs {
+		entries, _, err := client.Schedules.EntriesContext(ctx, id, &pagerduty.ScheduleEntriesOptions{
+			Since: opts.Since,
+			Until: opts.Until,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("report: fetching entries of schedule %s: %w", id, err)
+		}
+
+		for _, e := range entries {
+			b.addEntry(e)
+		}
+	}
+
+	incidents, err := client.Incidents.ListAllContext(ctx, &pagerduty.IncidentListOptions{
+		Since: opts.Since,
+		Until: opts.Until,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("report: fetching incidents: %w", err)
+	}
+	for i := range incidents {
+		b.addIncident(&incidents[i])
+	}
+
+	if client.APIVersion() == pagerduty.APIVersion2 {
+		// the REST API v2 has no alerts, but logs the notifications
+		entries, err := client.LogEntries.ListAllContext(ctx, &pagerduty.LogEntryListOptions{
+			Since: opts.Since,
+			Until: opts.Until,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("report: fetching log entries: %w", err)
+		}
+		for i := range entries {
+			b.addLogEntry(&entries[i])
+		}
+	} else {
+		alerts, err := client.Alerts.ListAllContext(ctx, &pagerduty.AlertListOptions{
+			Since: opts.Since,
+			Until: opts.Until,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("report: fetching alerts: %w", err)
+		}
+		for i := range alerts {
+			b.addAlert(&alerts[i])
+		}
+	}
+
+	// off-hours depend on the time zone of the users, which the entries and
+	// incidents do not include
+	for id, u := range b.users {
+		if u.user.TimeZone != nil {
+			continue
+		}
+
+		user, _, err := client.Users.GetContext(ctx, id, nil)
+		if err != nil {
+			return nil, fmt.Errorf("report: fetching user %s: %w", id, err)
+		}
+		u.merge(user)
+	}
+
+	return b.report(), nil
+}
+
+// userLoad accumulates the load of a user.
+type userLoad struct {
+	user          pagerduty.User
+	entries       []pagerduty.RenderedScheduleEntry
+	incidents     int
+	notifications []time.Time
+}
+
+// merge completes the details of the user with the ones of u.
+func (l *userLoad) merge(u *pagerduty.User) {
+	if u == nil {
+		return
+	}
+	if l.user.Name == nil {
+		l.user.Name = u.Name
+	}
+	if l.user.Email == nil {
+		l.user.Email = u.Email
+	}
+	if l.user.TimeZone == nil {
+		l.user.TimeZone = u.TimeZone
+	}
+}
+
+type builder struct {
+	opts  Options
+	users map[string]*userLoad
+}
+
+func newBuilder(opts *Options) *builder {
+	b := &builder{opts: *opts, users: make(map[string]*userLoad)}
+	if b.opts.WorkdayStart == b.opts.WorkdayEnd {
+		b.opts.WorkdayStart = pagerduty.TimeOfDay{Hour: 9}
+		b.opts.WorkdayEnd = pagerduty.TimeOfDay{Hour: 17}
+	}
+	if len(b.opts.Workdays) == 0 {
+		b.opts.Workdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	if b.opts.TimeZone == nil {
+		b.opts.TimeZone = time.UTC
+	}
+
+	return b
+}
+
+// user returns the load of u, or nil if u has no id.
+func (b *builder) user(u *pagerduty.User) *userLoad {
+	if u == nil || u.ID == nil {
+		return nil
+	}
+
+	l, ok := b.users[*u.ID]
+	if !ok {
+		l = &userLoad{user: pagerduty.User{ID: u.ID}}
+		b.users[*u.ID] = l
+	}
+	l.merge(u)
+
+	return l
+}
+
+func (b *builder) addEntry(e pagerduty.RenderedScheduleEntry) {
+	if e.Start == nil || e.End == nil {
+		return
+	}
+
+	if l := b.user(e.User); l != nil {
+		l.entries = append(l.entries, e)
+	}
+}
+
+// addIncident counts the incident once for every user who acknowledged,
+// resolved or was assigned it.
+func (b *builder) addIncident(incident *pagerduty.Incident) {
+	handlers := make(map[string]*pagerduty.User)
+	add := func(u *pagerduty.User) {
+		// REST API v2 references may also point to services
+		if u == nil || u.ID == nil || (u.Type != nil && *u.Type != pagerduty.ObjectTypeUser && *u.Type != pagerduty.ObjectTypeUserReference) {
+			return
+		}
+		handlers[*u.ID] = u
+	}
+
+	for _, objects := range [][]pagerduty.ObjectAt{incident.Acknowledgers, incident.AssignedTo} {
+		for _, o := range objects {
+			if o.Type == nil || (*o.Type != pagerduty.ObjectTypeUser && *o.Type != pagerduty.ObjectTypeUserReference) {
+				continue
+			}
+			if id, ok := o.Object["id"].(string); ok {
+				u := &pagerduty.User{ID: pagerduty.String(id)}
+				if name, ok := o.Object["name"].(string); ok {
+					u.Name = pagerduty.String(name)
+				}
+				add(u)
+			}
+		}
+	}
+	for _, a := range incident.Assignments {
+		add(a.Assignee)
+	}
+	for _, a := range incident.Acknowledgements {
+		add(a.Acknowledger)
+	}
+	if incident.Status != nil && *incident.Status == pagerduty.StatusResolved {
+		add(incident.LastStatusChangeBy)
+	}
+
+	for _, u := range handlers {
+		b.user(u).incidents++
+	}
+}
+
+func (b *builder) addAlert(alert *pagerduty.Alert) {
+	if alert.StartedAt == nil {
+		return
+	}
+
+	if l := b.user(alert.User); l != nil {
+		l.notifications = append(l.notifications, *alert.StartedAt)
+	}
+}
+
+// addLogEntry counts the notifications logged by notify log entries.
+func (b *builder) addLogEntry(e *pagerduty.LogEntry) {
+	notify, ok := e.Details.(*pagerduty.NotifyLogEntry)
+	if !ok || e.CreatedAt == nil {
+		return
+	}
+
+	if l := b.user(notify.User); l != nil {
+		l.notifications = append(l.notifications, *e.CreatedAt)
+	}
+}
+
+func (b *builder) report() *Report {
+	r := &Report{Since: b.opts.Since, Until: b.opts.Until}
+
+	var total float64
+	for _, l := range b.users {
+		loc := b.opts.TimeZone
+		if l.user.TimeZone != nil && l.user.TimeZone.Location != nil {
+			loc = l.user.TimeZone.Location
+		}
+
+		load := UserLoad{
+			UserID:        *l.user.ID,
+			TimeZone:      loc.String(),
+			Incidents:     l.incidents,
+			Interruptions: len(l.notifications),
+		}
+		if l.user.Name != nil {
+			load.Name = *l.user.Name
+		}
+		if l.user.Email != nil {
+			load.Email = *l.user.Email
+		}
+
+		var onCall, working time.Duration
+		for _, span := range spans(l.entries) {
+			onCall += span[1].Sub(span[0])
+			working += b.workingTime(span[0], span[1], loc)
+		}
+		load.OnCallHours = onCall.Hours()
+		load.OffHoursHours = (onCall - working).Hours()
+		total += load.OnCallHours
+
+		for _, t := range l.notifications {
+			if b.workingTime(t, t.Add(time.Nanosecond), loc) == 0 {
+				load.OffHoursInterruptions++
+			}
+		}
+
+		r.Users = append(r.Users, load)
+	}
+
+	for i := range r.Users {
+		if total > 0 {
+			r.Users[i].OnCallShare = 100 * r.Users[i].OnCallHours / total
+		}
+	}
+
+	sort.Slice(r.Users, func(i, j int) bool {
+		if r.Users[i].OnCallHours != r.Users[j].OnCallHours {
+			return r.Users[i].OnCallHours > r.Users[j].OnCallHours
+		}
+		return r.Users[i].UserID < r.Users[j].UserID
+	})
+
+	return r
+}
+
+// spans returns the spans of time covered by entries, merging the ones that
+// overlap, so that being on call on several schedules at once counts once.
+func spans(entries []pagerduty.RenderedScheduleEntry) [][2]time.Time {
+	sorted := append([]pagerduty.RenderedScheduleEntry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(*sorted[j].Start) })
+
+	var merged [][2]time.Time
+	for _, e := range sorted {
+		if n := len(merged); n > 0 && !e.Start.After(merged[n-1][1]) {
+			if e.End.After(merged[n-1][1]) {
+				merged[n-1][1] = *e.End
+			}
+			continue
+		}
+
+		merged = append(merged, [2]time.Time{*e.Start, *e.End})
+	}
+
+	return merged
+}
+
+// workingTime returns the part of the span from start to end that falls in
+// working hours in loc.
+func (b *builder) workingTime(start, end time.Time, loc *time.Location) time.Duration {
+	workday := make(map[time.Weekday]bool)
+	for _, d := range b.opts.Workdays {
+		workday[d] = true
+	}
+
+	// working hours spanning midnight may have started the day before start
+	var d time.Duration
+	year, month, day := start.In(loc).AddDate(0, 0, -1).Date()
+	for date := time.Date(year, month, day, 0, 0, 0, 0, loc); date.Before(end); date = date.AddDate(0, 0, 1) {
+		if !workday[date.Weekday()] {
+			continue
+		}
+
+		from := time.Date(date.Year(), date.Month(), date.Day(), b.opts.WorkdayStart.Hour, b.opts.WorkdayStart.Minute, b.opts.WorkdayStart.Second, 0, loc)
+		to := time.Date(date.Year(), date.Month(), date.Day(), b.opts.WorkdayEnd.Hour, b.opts.WorkdayEnd.Minute, b.opts.WorkdayEnd.Second, 0, loc)
+		if !to.After(from) {
+			// working hours spanning midnight
+			to = to.AddDate(0, 0, 1)
+		}
+
+		if start.After(from) {
+			from = start
+		}
+		if end.Before(to) {
+			to = end
+		}
+		if to.After(from) {
+			d += to.Sub(from)
+		}
+	}
+
+	return d
+}
+
+// csvHeader is the header row of the CSV output.
+var csvHeader = []string{
+	"user_id",
+	"name",
+	"email",
+	"time_zone",
+	"on_call_hours",
+	"off_hours_hours",
+	"on_call_share",
+	"incidents",
+	"interruptions",
+	"off_hours_interruptions",
+}
+
+// WriteCSV writes the report to w as CSV, one row per user after a header
+// row.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	hours := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+	for _, u := range r.Users {
+		err := cw.Write([]string{
+			u.UserID,
+			u.Name,
+			u.Email,
+			u.TimeZone,
+			hours(u.OnCallHours),
+			hours(u.OffHoursHours),
+			hours(u.OnCallShare),
+			strconv.Itoa(u.Incidents),
+			strconv.Itoa(u.Interruptions),
+			strconv.Itoa(u.OffHoursInterruptions),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
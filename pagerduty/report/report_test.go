@@ -0,0 +1,272 @@
+package report_test
+
+import (
+	"github.com/hudl/go-pagerduty/pagerduty"
+	. "github.com/hudl/go-pagerduty/pagerduty/report"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	primaryEntriesJSON = `{"entries": [
+		{"start": "2015-11-09T09:00:00Z", "end": "2015-11-10T09:00:00Z", "user": {"id": "PALICE1", "name": "Alice"}},
+		{"start": "2015-11-10T09:00:00Z", "end": "2015-11-10T21:00:00Z", "user": {"id": "PBOB001", "name": "Bob"}}
+	]}`
+	secondaryEntriesJSON = `{"entries": [
+		{"start": "2015-11-09T12:00:00Z", "end": "2015-11-09T18:00:00Z", "user": {"id": "PALICE1", "name": "Alice"}}
+	]}`
+	incidentsJSON = `{"total": 2, "incidents": [
+		{
+			"id": "PINC001",
+			"status": "resolved",
+			"acknowledgers": [{"at": "2015-11-09T10:00:00Z", "object": {"id": "PALICE1", "type": "user"}}],
+			"last_status_change_by": {"id": "PALICE1"}
+		},
+		{
+			"id": "PINC002",
+			"status": "acknowledged",
+			"assigned_to": [{"at": "2015-11-10T15:00:00Z", "object": {"id": "PBOB001", "type": "user"}}]
+		}
+	]}`
+	alertsJSON = `{"total": 2, "alerts": [
+		{"id": "PALERT1", "type": "SMS", "started_at": "2015-11-09T03:00:00Z", "user": {"id": "PALICE1"}},
+		{"id": "PALERT2", "type": "Phone", "started_at": "2015-11-10T15:00:00Z", "user": {"id": "PBOB001"}}
+	]}`
+	aliceJSON = `{"user": {"id": "PALICE1", "name": "Alice", "email": "alice@example.com", "time_zone": "UTC"}}`
+	bobJSON   = `{"user": {"id": "PBOB001", "name": "Bob", "email": "bob@example.com", "time_zone": "America/New_York"}}`
+)
+
+var _ = Describe("Report", func() {
+	var (
+		server *ghttp.Server
+		client *pagerduty.Client
+
+		// a Monday
+		since = time.Date(2015, time.November, 9, 0, 0, 0, 0, time.UTC)
+		until = since.AddDate(0, 0, 7)
+
+		report *Report
+		err    error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = pagerduty.NewClient(nil, "subdomain", "super-secret-key")
+		client.BaseURL, _ = url.Parse(server.URL())
+
+		for path, body := range map[string]string{
+			"/schedules/PRIMARY/entries": primaryEntriesJSON,
+			"/schedules/SECONDA/entries": secondaryEntriesJSON,
+			"/incidents":                 incidentsJSON,
+			"/alerts":                    alertsJSON,
+			"/users/PALICE1":             aliceJSON,
+			"/users/PBOB001":             bobJSON,
+		} {
+			server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, body))
+		}
+	})
+
+	AfterEach(func() { server.Close() })
+
+	Describe("Generate", func() {
+		BeforeEach(func() {
+			report, err = Generate(context.Background(), client, &Options{
+				Since:       since,
+				Until:       until,
+				ScheduleIDs: []string{"PRIMARY", "SECONDA"},
+			})
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should have fetched the entries, incidents, alerts and users", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(6))
+		})
+
+		It("should return the load of every user by decreasing on-call hours", func() {
+			Expect(report.Since).To(Equal(since))
+			Expect(report.Until).To(Equal(until))
+			Expect(report.Users).To(HaveLen(2))
+			Expect(report.Users[0].UserID).To(Equal("PALICE1"))
+			Expect(report.Users[1].UserID).To(Equal("PBOB001"))
+		})
+
+		It("should count overlapping on-call entries once", func() {
+			alice := report.Users[0]
+			Expect(alice.OnCallHours).To(Equal(24.0))
+			Expect(alice.OnCallShare).To(BeNumerically("~", 200.0/3, 0.001))
+		})
+
+		It("should count off-hours in the time zone of the user", func() {
+			// Monday 09:00 to Tuesday 09:00 in UTC
+			Expect(report.Users[0].OffHoursHours).To(Equal(16.0))
+
+			// Tuesday 04:00 to 16:00 in New York
+			bob := report.Users[1]
+			Expect(bob.TimeZone).To(Equal("America/New_York"))
+			Expect(bob.OnCallHours).To(Equal(12.0))
+			Expect(bob.OffHoursHours).To(Equal(5.0))
+		})
+
+		It("should count the incidents handled once per user", func() {
+			Expect(report.Users[0].Incidents).To(Equal(1))
+			Expect(report.Users[1].Incidents).To(Equal(1))
+		})
+
+		It("should count the interruptions", func() {
+			Expect(report.Users[0].Interruptions).To(Equal(1))
+			Expect(report.Users[0].OffHoursInterruptions).To(Equal(1))
+			Expect(report.Users[1].Interruptions).To(Equal(1))
+			Expect(report.Users[1].OffHoursInterruptions).To(Equal(0))
+		})
+
+		It("should fill in the details of the users", func() {
+			Expect(report.Users[0].Name).To(Equal("Alice"))
+			Expect(report.Users[0].Email).To(Equal("alice@example.com"))
+		})
+
+		It("should reject an empty date range", func() {
+			_, err = Generate(context.Background(), client, &Options{Since: since, Until: since})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Generate with working hours spanning midnight", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/schedules/NIGHTLY/entries", ghttp.RespondWith(http.StatusOK, `{"entries": [
+				{"start": "2015-11-10T02:00:00Z", "end": "2015-11-10T08:00:00Z", "user": {"id": "PALICE1"}}
+			]}`))
+
+			report, err = Generate(context.Background(), client, &Options{
+				Since:        since,
+				Until:        until,
+				ScheduleIDs:  []string{"NIGHTLY"},
+				WorkdayStart: pagerduty.TimeOfDay{Hour: 22},
+				WorkdayEnd:   pagerduty.TimeOfDay{Hour: 6},
+			})
+		})
+
+		It("should count the working hours started the day before", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			// Tuesday 02:00 to 08:00, in the hours started on Monday 22:00
+			alice := report.Users[0]
+			Expect(alice.UserID).To(Equal("PALICE1"))
+			Expect(alice.OnCallHours).To(Equal(6.0))
+			Expect(alice.OffHoursHours).To(Equal(2.0))
+		})
+	})
+
+	Describe("Generate with the REST API v2", func() {
+		BeforeEach(func() {
+			client = pagerduty.NewClientV2(nil, "super-secret-key", "")
+			client.BaseURL, _ = url.Parse(server.URL())
+
+			for path, body := range map[string]string{
+				"/schedules/PRIMARY": `{"schedule": {"id": "PRIMARY", "final_schedule": {"rendered_schedule_entries": [
+					{"start": "2015-11-10T09:00:00Z", "end": "2015-11-10T21:00:00Z", "user": {"id": "PBOB001"}}
+				]}}}`,
+				"/incidents": `{"more": false, "incidents": [
+					{
+						"id": "PINC001",
+						"status": "acknowledged",
+						"assignments": [{"at": "2015-11-10T15:00:00Z", "assignee": {"id": "PBOB001", "type": "user_reference"}}],
+						"acknowledgements": [{"at": "2015-11-10T15:05:00Z", "acknowledger": {"id": "PBOB001", "type": "user_reference"}}]
+					},
+					{
+						"id": "PINC002",
+						"status": "triggered",
+						"assignments": [{"at": "2015-11-10T16:00:00Z", "assignee": {"id": "PBOB001", "type": "user_reference"}}]
+					},
+					{
+						"id": "PINC003",
+						"status": "acknowledged",
+						"acknowledgements": [{"at": "2015-11-10T17:00:00Z", "acknowledger": {"id": "PSVC001", "type": "service_reference"}}]
+					}
+				]}`,
+				"/log_entries": `{"more": false, "log_entries": [
+					{"id": "PLOG001", "type": "notify_log_entry", "created_at": "2015-11-10T03:00:00Z", "user": {"id": "PBOB001"}},
+					{"id": "PLOG002", "type": "notify_log_entry", "created_at": "2015-11-10T15:00:00Z", "user": {"id": "PBOB001"}},
+					{"id": "PLOG003", "type": "acknowledge_log_entry", "created_at": "2015-11-10T15:05:00Z", "agent": {"id": "PBOB001"}}
+				]}`,
+			} {
+				server.RouteToHandler("GET", path, ghttp.RespondWith(http.StatusOK, body))
+			}
+
+			report, err = Generate(context.Background(), client, &Options{
+				Since:       since,
+				Until:       until,
+				ScheduleIDs: []string{"PRIMARY"},
+			})
+		})
+
+		It("should not return an error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should count the incidents assigned to or acknowledged by the users", func() {
+			Expect(report.Users).To(HaveLen(1))
+			Expect(report.Users[0].UserID).To(Equal("PBOB001"))
+			Expect(report.Users[0].Incidents).To(Equal(2))
+		})
+
+		It("should count the notifications of the log entries as interruptions", func() {
+			Expect(report.Users).To(HaveLen(1))
+			Expect(report.Users[0].Interruptions).To(Equal(2))
+			Expect(report.Users[0].OffHoursInterruptions).To(Equal(1))
+		})
+	})
+
+	Describe("output", func() {
+		BeforeEach(func() {
+			report = &Report{
+				Since: since,
+				Until: until,
+				Users: []UserLoad{{
+					UserID:                "PALICE1",
+					Name:                  "Alice, A.",
+					TimeZone:              "UTC",
+					OnCallHours:           24,
+					OffHoursHours:         16,
+					OnCallShare:           200.0 / 3,
+					Incidents:             1,
+					Interruptions:         2,
+					OffHoursInterruptions: 1,
+				}},
+			}
+		})
+
+		It("should write CSV", func() {
+			var buf bytes.Buffer
+			Expect(report.WriteCSV(&buf)).To(Succeed())
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			Expect(lines).To(Equal([]string{
+				"user_id,name,email,time_zone,on_call_hours,off_hours_hours,on_call_share,incidents,interruptions,off_hours_interruptions",
+				`PALICE1,"Alice, A.",,UTC,24.00,16.00,66.67,1,2,1`,
+			}))
+		})
+
+		It("should write JSON", func() {
+			var buf bytes.Buffer
+			Expect(report.WriteJSON(&buf)).To(Succeed())
+
+			var decoded Report
+			Expect(json.Unmarshal(buf.Bytes(), &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(*report))
+			Expect(buf.String()).To(ContainSubstring(`"off_hours_hours": 16`))
+		})
+	})
+})
@@ -0,0 +1,183 @@
+package pagerduty
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	RestrictionTypeDaily  = "daily_restriction"
+	RestrictionTypeWeekly = "weekly_restriction"
+)
+
+// TimeOfDay is a wall clock time, such as the start of a restriction, which
+// marshals to and unmarshals from the 'HH:MM:SS' format of the API.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+
+	var tod TimeOfDay
+	_, err := fmt.Sscanf(s, "%d:%d:%d", &tod.Hour, &tod.Minute, &tod.Second)
+	if err != nil {
+		return fmt.Errorf("time of day %q is not of the form HH:MM:SS", s)
+	}
+
+	*t = tod
+	return nil
+}
+
+// on returns the time t is at on the given day in loc.
+func (t TimeOfDay) on(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, t.Hour, t.Minute, t.Second, 0, loc)
+}
+
+// Restriction limits a schedule layer to recurring spans of time, such as
+// business hours. Daily restrictions repeat every day, weekly restrictions
+// every week from StartDayOfWeek.
+type Restriction struct {
+	Type            *string    `json:"type,omitempty"`
+	StartTimeOfDay  *TimeOfDay `json:"start_time_of_day,omitempty"`
+	DurationSeconds *int       `json:"duration_seconds,omitempty"`
+
+	// The ISO 8601 day of the week weekly restrictions start on, from 1 for
+	// Monday to 7 for Sunday.
+	StartDayOfWeek *int `json:"start_day_of_week,omitempty"`
+}
+
+// NewDailyRestriction returns a restriction starting every day at start and
+// lasting d.
+func NewDailyRestriction(start TimeOfDay, d time.Duration) Restriction {
+	return Restriction{
+		Type:            String(RestrictionTypeDaily),
+		StartTimeOfDay:  &start,
+		DurationSeconds: Int(int(d / time.Second)),
+	}
+}
+
+// NewWeeklyRestriction returns a restriction starting every week on day at
+// start and lasting d.
+func NewWeeklyRestriction(day time.Weekday, start TimeOfDay, d time.Duration) Restriction {
+	isoDay := int(day)
+	if day == time.Sunday {
+		isoDay = 7
+	}
+
+	return Restriction{
+		Type:            String(RestrictionTypeWeekly),
+		StartTimeOfDay:  &start,
+		DurationSeconds: Int(int(d / time.Second)),
+		StartDayOfWeek:  Int(isoDay),
+	}
+}
+
+// BusinessHoursRestrictions returns the restrictions limiting a layer to the
+// hours between start and end from Monday to Friday, e.g. 09:00 to 17:00.
+func BusinessHoursRestrictions(start, end TimeOfDay) []Restriction {
+	d := between(start, end)
+
+	var r []Restriction
+	for day := time.Monday; day <= time.Friday; day++ {
+		r = append(r, NewWeeklyRestriction(day, start, d))
+	}
+
+	return r
+}
+
+// WeekendRestrictions returns the restrictions limiting a layer to weekends,
+// from Saturday 00:00 to Monday 00:00.
+func WeekendRestrictions() []Restriction {
+	return []Restriction{NewWeeklyRestriction(time.Saturday, TimeOfDay{}, 48*time.Hour)}
+}
+
+// NightRestrictions returns the restrictions limiting a layer to the hours
+// between start and end every day, ending the next day if end is before
+// start, e.g. 18:00 to 08:00.
+func NightRestrictions(start, end TimeOfDay) []Restriction {
+	return []Restriction{NewDailyRestriction(start, between(start, end))}
+}
+
+// between returns the duration from start to the next occurrence of end.
+func between(start, end TimeOfDay) time.Duration {
+	d := end.on(1, 1, 1, time.UTC).Sub(start.on(1, 1, 1, time.UTC))
+	if d <= 0 {
+		d += 24 * time.Hour
+	}
+
+	return d
+}
+
+// Contains reports whether t falls inside a span of the restriction, with the
+// start of the spans read as wall clock times in loc. A nil loc is UTC.
+func (r *Restriction) Contains(t time.Time, loc *time.Location) bool {
+	if r.Type == nil || r.StartTimeOfDay == nil || r.DurationSeconds == nil {
+		return false
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t = t.In(loc)
+	d := time.Duration(*r.DurationSeconds) * time.Second
+	year, month, day := t.Date()
+
+	// the days the span containing t may have started on
+	var daysBack []int
+	switch *r.Type {
+	case RestrictionTypeDaily:
+		daysBack = []int{0, 1}
+	case RestrictionTypeWeekly:
+		if r.StartDayOfWeek == nil {
+			return false
+		}
+		offset := (int(t.Weekday()) - *r.StartDayOfWeek%7 + 7) % 7
+		daysBack = []int{offset, offset + 7}
+	default:
+		return false
+	}
+
+	for _, back := range daysBack {
+		start := r.StartTimeOfDay.on(year, month, day-back, loc)
+		if !t.Before(start) && t.Before(start.Add(d)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InRestrictions reports whether t falls inside the restrictions of the
+// layer, read in the time zone of its schedule. A layer without restrictions
+// contains any time. A nil tz is UTC.
+func (l *ScheduleLayer) InRestrictions(t time.Time, tz *TimeZone) bool {
+	if len(l.Restrictions) == 0 {
+		return true
+	}
+
+	var loc *time.Location
+	if tz != nil {
+		loc = tz.Location
+	}
+
+	for i := range l.Restrictions {
+		if l.Restrictions[i].Contains(t, loc) {
+			return true
+		}
+	}
+
+	return false
+}
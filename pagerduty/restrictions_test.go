@@ -0,0 +1,131 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"encoding/json"
+	"time"
+)
+
+const restrictionLayerJSON = `{
+	"name": "Business hours",
+	"restriction_type": "weekly_restriction",
+	"restrictions": [
+		{
+			"type": "daily_restriction",
+			"start_time_of_day": "18:00:00",
+			"duration_seconds": 50400
+		},
+		{
+			"type": "weekly_restriction",
+			"start_time_of_day": "09:00:00",
+			"duration_seconds": 28800,
+			"start_day_of_week": 1
+		}
+	]
+}`
+
+var _ = Describe("Restrictions", func() {
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	Describe("JSON", func() {
+		var layer ScheduleLayer
+
+		BeforeEach(func() {
+			layer = ScheduleLayer{}
+			Expect(json.Unmarshal([]byte(restrictionLayerJSON), &layer)).To(Succeed())
+		})
+
+		It("should decode typed restrictions", func() {
+			Expect(layer.Restrictions).To(HaveLen(2))
+			Expect(layer.Restrictions[0]).To(Equal(NightRestrictions(TimeOfDay{Hour: 18}, TimeOfDay{Hour: 8})[0]))
+			Expect(layer.Restrictions[1]).To(Equal(NewWeeklyRestriction(time.Monday, TimeOfDay{Hour: 9}, 8*time.Hour)))
+		})
+
+		It("should encode the restrictions back", func() {
+			data, err := json.Marshal(&layer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(restrictionLayerJSON))
+		})
+
+		It("should reject malformed times of day", func() {
+			var tod TimeOfDay
+			Expect(json.Unmarshal([]byte(`"noon"`), &tod)).NotTo(Succeed())
+		})
+	})
+
+	Describe("constructors", func() {
+		It("should build business hours from Monday to Friday", func() {
+			r := BusinessHoursRestrictions(TimeOfDay{Hour: 9}, TimeOfDay{Hour: 17, Minute: 30})
+			Expect(r).To(HaveLen(5))
+			Expect(*r[0].StartDayOfWeek).To(Equal(1))
+			Expect(*r[4].StartDayOfWeek).To(Equal(5))
+			Expect(*r[0].DurationSeconds).To(Equal(int((8*time.Hour + 30*time.Minute) / time.Second)))
+		})
+
+		It("should build weekends from Saturday to Monday", func() {
+			r := WeekendRestrictions()
+			Expect(r).To(HaveLen(1))
+			Expect(*r[0].StartDayOfWeek).To(Equal(6))
+			Expect(*r[0].DurationSeconds).To(Equal(2 * 24 * 60 * 60))
+		})
+
+		It("should build nights spanning midnight", func() {
+			r := NightRestrictions(TimeOfDay{Hour: 18}, TimeOfDay{Hour: 8})
+			Expect(*r[0].Type).To(Equal(RestrictionTypeDaily))
+			Expect(*r[0].DurationSeconds).To(Equal(14 * 60 * 60))
+		})
+
+		It("should use ISO days of the week", func() {
+			r := NewWeeklyRestriction(time.Sunday, TimeOfDay{}, time.Hour)
+			Expect(*r.StartDayOfWeek).To(Equal(7))
+		})
+	})
+
+	Describe("Contains", func() {
+		It("should contain times inside a daily span spanning midnight", func() {
+			r := NightRestrictions(TimeOfDay{Hour: 18}, TimeOfDay{Hour: 8})[0]
+
+			Expect(r.Contains(time.Date(2015, 11, 10, 19, 0, 0, 0, time.UTC), nil)).To(BeTrue())
+			Expect(r.Contains(time.Date(2015, 11, 10, 7, 59, 59, 0, time.UTC), nil)).To(BeTrue())
+			Expect(r.Contains(time.Date(2015, 11, 10, 8, 0, 0, 0, time.UTC), nil)).To(BeFalse())
+			Expect(r.Contains(time.Date(2015, 11, 10, 12, 0, 0, 0, time.UTC), nil)).To(BeFalse())
+		})
+
+		It("should contain times inside a weekly span", func() {
+			r := WeekendRestrictions()[0]
+
+			Expect(r.Contains(time.Date(2015, 11, 14, 0, 0, 0, 0, time.UTC), nil)).To(BeTrue())   // Saturday
+			Expect(r.Contains(time.Date(2015, 11, 15, 23, 0, 0, 0, time.UTC), nil)).To(BeTrue())  // Sunday
+			Expect(r.Contains(time.Date(2015, 11, 16, 0, 0, 0, 0, time.UTC), nil)).To(BeFalse())  // Monday
+			Expect(r.Contains(time.Date(2015, 11, 13, 23, 0, 0, 0, time.UTC), nil)).To(BeFalse()) // Friday
+		})
+
+		It("should read the restriction in the given time zone", func() {
+			r := BusinessHoursRestrictions(TimeOfDay{Hour: 9}, TimeOfDay{Hour: 17})[0]
+
+			// Monday 09:30 in New York is 14:30 UTC
+			t := time.Date(2015, 11, 9, 14, 30, 0, 0, time.UTC)
+			Expect(r.Contains(t, newYork)).To(BeTrue())
+			Expect(r.Contains(t.Add(-time.Hour), newYork)).To(BeFalse())
+		})
+	})
+
+	Describe("InRestrictions", func() {
+		It("should contain any time without restrictions", func() {
+			layer := &ScheduleLayer{}
+			Expect(layer.InRestrictions(time.Now(), nil)).To(BeTrue())
+		})
+
+		It("should honor the time zone of the schedule", func() {
+			layer := &ScheduleLayer{Restrictions: BusinessHoursRestrictions(TimeOfDay{Hour: 9}, TimeOfDay{Hour: 17})}
+			t := time.Date(2015, 11, 9, 21, 30, 0, 0, time.UTC) // Monday 16:30 in New York
+
+			Expect(layer.InRestrictions(t, &TimeZone{newYork})).To(BeTrue())
+			Expect(layer.InRestrictions(t, nil)).To(BeFalse())
+		})
+	})
+})
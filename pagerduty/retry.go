@@ -0,0 +1,170 @@
+package pagerduty
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	headerRetryAfter     = "Retry-After"
+	headerRateLimitReset = "Ratelimit-Reset"
+)
+
+// RetryPolicy configures how a Client retries requests that failed because
+// of rate limiting (429), a server error (5xx) or a transport error. Retries
+// apply to both REST and Events API requests.
+type RetryPolicy struct {
+	// The maximum number of attempts made for a single request, including the
+	// first one. Values less than 2 disable retries.
+	MaxAttempts int
+
+	// The delay before the first retry. The delay doubles with every attempt
+	// and is randomized (jittered) to avoid many clients retrying in lockstep.
+	MinBackoff time.Duration
+
+	// The upper bound for the computed backoff delay. Delays requested by the
+	// server through the 'Retry-After' or 'Ratelimit-Reset' headers are always
+	// honored, even when they exceed this value.
+	MaxBackoff time.Duration
+
+	// POST requests are not idempotent, so by default they are only retried
+	// when the server rejected them with a 429, which guarantees that they
+	// were not processed. Set this to also retry them on server and transport
+	// errors.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a sensible retry policy for most use cases. It is not
+// enabled unless it is assigned to Client.RetryPolicy.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// backoff returns the jittered delay to wait before the given retry attempt,
+// starting at 1 for the first retry.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	// "equal jitter": wait at least half of the delay
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// retryable reports whether a request with the given method that resulted in
+// resp or err should be attempted again.
+func (p *RetryPolicy) retryable(method string, resp *http.Response, err error) bool {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if method == POST && !p.RetryNonIdempotent {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode >= 500
+}
+
+// retryAfter returns the delay requested by the server, if any. The standard
+// 'Retry-After' header (either in seconds or as an HTTP date) takes
+// precedence over PagerDuty's 'Ratelimit-Reset' header.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if v := resp.Header.Get(headerRetryAfter); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			d := t.Sub(time.Now())
+			if d < 0 {
+				d = 0
+			}
+			return d, true
+		}
+	}
+
+	if v := resp.Header.Get(headerRateLimitReset); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+
+	return 0, false
+}
+
+// send performs req bound to ctx, retrying it according to the client's
+// RetryPolicy. The body of the returned response must be closed by the
+// caller.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	policy := c.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		r := req.WithContext(ctx)
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		resp, err := c.client.Do(r)
+		if err != nil {
+			// prefer the context's error, it is more helpful to the caller
+			// than the wrapped transport error
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+		}
+
+		if policy == nil || attempt >= policy.MaxAttempts ||
+			!policy.retryable(req.Method, resp, err) ||
+			(req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		wait, ok := retryAfter(resp)
+		if !ok {
+			wait = policy.backoff(attempt)
+		}
+
+		if resp != nil {
+			// drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"context"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Retries", func() {
+	var (
+		env  *TestEnvironment
+		resp *Response
+		err  error
+	)
+
+	BeforeEach(func() {
+		env = NewTestEnvironment()
+		env.Client.RetryPolicy = &RetryPolicy{
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  2 * time.Millisecond,
+		}
+	})
+
+	AfterEach(func() { env.Server.Close() })
+
+	Context("without a retry policy", func() {
+		BeforeEach(func() {
+			env.Client.RetryPolicy = nil
+			env.Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, nil),
+				ghttp.RespondWith(http.StatusOK, `{ "total": 1 }`),
+			)
+
+			resp, err = env.Client.Get("/", nil)
+		})
+
+		It("should have made a single request", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+
+	Context("when a GET request fails with a server error", func() {
+		BeforeEach(func() {
+			env.Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, nil),
+				ghttp.RespondWith(http.StatusBadGateway, nil),
+				ghttp.RespondWith(http.StatusOK, `{ "total": 1 }`),
+			)
+
+			resp, err = env.Client.Get("/", nil)
+		})
+
+		It("should retry until it succeeds", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("should return the successful response", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Total).To(Equal(1))
+		})
+	})
+
+	Context("when every attempt fails", func() {
+		BeforeEach(func() {
+			env.Server.AllowUnhandledRequests = true
+			env.Server.UnhandledRequestStatusCode = http.StatusInternalServerError
+
+			resp, err = env.Client.Get("/", nil)
+		})
+
+		It("should stop after the maximum number of attempts", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("should return the last error response", func() {
+			Expect(err).To(BeAssignableToTypeOf(new(ErrorResponse)))
+			Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Context("when a request is rate limited", func() {
+		var started time.Time
+
+		BeforeEach(func() {
+			env.Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, nil, http.Header{
+					"Retry-After": []string{"1"},
+				}),
+				ghttp.RespondWith(http.StatusOK, nil),
+			)
+
+			started = time.Now()
+			resp, err = env.Client.Get("/", nil)
+		})
+
+		It("should honor the Retry-After header", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+			Expect(time.Since(started)).To(BeNumerically(">=", time.Second))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when a POST request fails with a server error", func() {
+		BeforeEach(func() {
+			env.Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, nil),
+				ghttp.RespondWith(http.StatusOK, nil),
+			)
+
+			resp, err = env.Client.Post("/", map[string]string{"a": "b"}, nil)
+		})
+
+		It("should not retry the request", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when a POST request is rate limited", func() {
+		BeforeEach(func() {
+			env.Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, nil, http.Header{
+					"Ratelimit-Reset": []string{"0"},
+				}),
+				ghttp.CombineHandlers(
+					ghttp.VerifyJSON(`{ "a": "b" }`),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+
+			resp, err = env.Client.Post("/", map[string]string{"a": "b"}, nil)
+		})
+
+		It("should retry the request with the same body", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when an event request fails with a server error", func() {
+		var eventResp *EventResponse
+
+		BeforeEach(func() {
+			env.Client.RetryPolicy.RetryNonIdempotent = true
+			env.Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, eventErrorResponseJSON),
+				ghttp.RespondWith(http.StatusOK, eventSuccessResponseJSON),
+			)
+
+			eventResp, err = env.Client.Events.Trigger(new(Event))
+		})
+
+		It("should retry non-idempotent requests when allowed", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(eventResp.IncidentKey).To(Equal("incident_key"))
+		})
+	})
+
+	Context("when the context is canceled while waiting to retry", func() {
+		BeforeEach(func() {
+			env.Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, nil, http.Header{
+					"Retry-After": []string{"60"},
+				}),
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			resp, err = env.Client.GetContext(ctx, "/", nil)
+		})
+
+		It("should return the context's error", func() {
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			Expect(resp).To(BeNil())
+			Expect(err).To(Equal(context.DeadlineExceeded))
+		})
+	})
+})
@@ -0,0 +1,300 @@
+package pagerduty
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const dayLength = 24 * time.Hour
+
+// layerRotation is a schedule layer prepared for rendering.
+type layerRotation struct {
+	layer    *ScheduleLayer
+	priority int
+
+	start, end *time.Time
+	loc        *time.Location
+
+	// the start of the first turn of the rotation and the length of the
+	// turns, counted in days of the schedule time zone when they are whole
+	// days so that hand-offs keep their wall clock time across DST changes
+	virtualStart time.Time
+	turn         time.Duration
+	days         int
+}
+
+func newLayerRotation(layer *ScheduleLayer, index int, loc *time.Location) (*layerRotation, error) {
+	r := &layerRotation{layer: layer, priority: index + 1, loc: loc}
+	if layer.Priority != nil {
+		r.priority = *layer.Priority
+	}
+	if layer.Start != nil {
+		r.start = layer.Start.Time
+	}
+	if layer.End != nil {
+		r.end = layer.End.Time
+	}
+
+	switch {
+	case layer.RotationVirtualStart != nil:
+		r.virtualStart = *layer.RotationVirtualStart
+	case r.start != nil:
+		r.virtualStart = *r.start
+	default:
+		return nil, fmt.Errorf("pagerduty: schedule layer %d has neither a start nor a rotation virtual start", index)
+	}
+
+	if layer.RotationTurnLengthSeconds != nil {
+		r.turn = time.Duration(*layer.RotationTurnLengthSeconds) * time.Second
+	}
+	if r.turn <= 0 && len(layer.Users) > 1 {
+		return nil, fmt.Errorf("pagerduty: schedule layer %d rotates %d users without a positive turn length", index, len(layer.Users))
+	}
+	if r.turn > 0 && r.turn%dayLength == 0 {
+		r.days = int(r.turn / dayLength)
+	}
+
+	return r, nil
+}
+
+// turnStart returns the start of the nth turn of the rotation.
+func (r *layerRotation) turnStart(n int) time.Time {
+	if r.days > 0 {
+		return r.virtualStart.In(r.loc).AddDate(0, 0, n*r.days)
+	}
+
+	return r.virtualStart.Add(time.Duration(n) * r.turn)
+}
+
+// turnAt returns the index of the turn t falls in.
+func (r *layerRotation) turnAt(t time.Time) int {
+	if r.turn <= 0 {
+		return 0
+	}
+
+	n := int(t.Sub(r.virtualStart) / r.turn)
+	for !r.turnStart(n + 1).After(t) {
+		n++
+	}
+	for r.turnStart(n).After(t) {
+		n--
+	}
+
+	return n
+}
+
+// userAt returns the user on call in the layer at t, or nil if nobody is.
+func (r *layerRotation) userAt(t time.Time) *User {
+	users := r.layer.Users
+	if len(users) == 0 ||
+		(r.start != nil && t.Before(*r.start)) ||
+		(r.end != nil && !t.Before(*r.end)) {
+		return nil
+	}
+
+	restricted := len(r.layer.Restrictions) > 0
+	for i := range r.layer.Restrictions {
+		if r.layer.Restrictions[i].Contains(t, r.loc) {
+			restricted = false
+			break
+		}
+	}
+	if restricted {
+		return nil
+	}
+
+	return &users[mod(r.turnAt(t), len(users))]
+}
+
+// changes adds to points the times in (since, until) at which the user on
+// call in the layer may change.
+func (r *layerRotation) changes(since, until time.Time, points []time.Time) []time.Time {
+	add := func(t time.Time) {
+		if t.After(since) && t.Before(until) {
+			points = append(points, t)
+		}
+	}
+
+	if r.start != nil {
+		add(*r.start)
+	}
+	if r.end != nil {
+		add(*r.end)
+	}
+
+	if r.turn > 0 && len(r.layer.Users) > 1 {
+		for n := r.turnAt(since) + 1; r.turnStart(n).Before(until); n++ {
+			add(r.turnStart(n))
+		}
+	}
+
+	for i := range r.layer.Restrictions {
+		restriction := &r.layer.Restrictions[i]
+		if restriction.StartTimeOfDay == nil || restriction.DurationSeconds == nil {
+			continue
+		}
+
+		d := time.Duration(*restriction.DurationSeconds) * time.Second
+		first := since.In(r.loc).AddDate(0, 0, -7)
+		for date := first; date.Before(until); date = date.AddDate(0, 0, 1) {
+			if restriction.Type != nil && *restriction.Type == RestrictionTypeWeekly &&
+				(restriction.StartDayOfWeek == nil || int(date.Weekday()) != *restriction.StartDayOfWeek%7) {
+				continue
+			}
+
+			year, month, dd := date.Date()
+			start := restriction.StartTimeOfDay.on(year, month, dd, r.loc)
+			add(start)
+			add(start.Add(d))
+		}
+	}
+
+	return points
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+// userKey identifies a user across the layers and overrides of a schedule.
+func userKey(u *User) string {
+	switch {
+	case u.ID != nil:
+		return *u.ID
+	case u.Email != nil:
+		return *u.Email
+	case u.Name != nil:
+		return *u.Name
+	}
+
+	return ""
+}
+
+// rotations returns the layers of the schedule prepared for rendering, by
+// decreasing priority, and the time zone of the schedule.
+func (s *Schedule) rotations() ([]*layerRotation, *time.Location, error) {
+	loc := time.UTC
+	if s.TimeZone != nil && s.TimeZone.Location != nil {
+		loc = s.TimeZone.Location
+	}
+
+	var layers []*layerRotation
+	for i := range s.ScheduleLayers {
+		r, err := newLayerRotation(&s.ScheduleLayers[i], i, loc)
+		if err != nil {
+			return nil, nil, err
+		}
+		layers = append(layers, r)
+	}
+	sort.SliceStable(layers, func(i, j int) bool { return layers[i].priority > layers[j].priority })
+
+	return layers, loc, nil
+}
+
+// changePoints returns the sorted times in [since, until] at which the user
+// on call in the layers or overrides may change.
+func changePoints(since, until time.Time, layers []*layerRotation, overrides []Override) []time.Time {
+	points := []time.Time{since, until}
+	for _, r := range layers {
+		points = r.changes(since, until, points)
+	}
+	for _, o := range overrides {
+		for _, t := range []*time.Time{o.Start, o.End} {
+			if t != nil && t.After(since) && t.Before(until) {
+				points = append(points, *t)
+			}
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Before(points[j]) })
+
+	return points
+}
+
+// overrideAt returns the user the overrides put on call at t, the last one
+// listed winning, or nil if none does.
+func overrideAt(t time.Time, overrides []Override) *User {
+	for i := len(overrides) - 1; i >= 0; i-- {
+		o := overrides[i]
+		if o.User != nil && o.Start != nil && o.End != nil && !t.Before(*o.Start) && t.Before(*o.End) {
+			return o.User
+		}
+	}
+
+	return nil
+}
+
+// Render computes the final schedule between since and until offline, from
+// the layers of the schedule and the given overrides, as PagerDuty renders it:
+//
+//   - each layer rotates its users every RotationTurnLengthSeconds from its
+//     RotationVirtualStart, between its Start and End and inside its
+//     restrictions, which are read in the time zone of the schedule;
+//   - at any time, the layer with the highest Priority that has someone on
+//     call wins, layers without a priority ranking by their position;
+//   - overrides win over every layer, the last one listed winning when they
+//     overlap.
+//
+// The entries are truncated to the range, rendered in the time zone of the
+// schedule, and consecutive entries of the same user are merged. Spans during
+// which nobody is on call have no entry.
+func (s *Schedule) Render(since, until time.Time, overrides []Override) ([]RenderedScheduleEntry, error) {
+	if !since.Before(until) {
+		return nil, fmt.Errorf("pagerduty: render range must end after it starts")
+	}
+
+	layers, loc, err := s.rotations()
+	if err != nil {
+		return nil, err
+	}
+
+	userAt := func(t time.Time) *User {
+		if u := overrideAt(t, overrides); u != nil {
+			return u
+		}
+
+		for _, r := range layers {
+			if u := r.userAt(t); u != nil {
+				return u
+			}
+		}
+
+		return nil
+	}
+
+	points := changePoints(since, until, layers, overrides)
+
+	var entries []RenderedScheduleEntry
+	for i := 0; i < len(points)-1; i++ {
+		start, end := points[i], points[i+1]
+		if !start.Before(end) {
+			continue
+		}
+
+		u := userAt(start)
+		if u == nil {
+			continue
+		}
+
+		if n := len(entries); n > 0 && entries[n-1].End.Equal(start) && userKey(entries[n-1].User) == userKey(u) {
+			entries[n-1].End = Time(end.In(loc))
+			continue
+		}
+
+		user := *u
+		entries = append(entries, RenderedScheduleEntry{Start: Time(start.In(loc)), End: Time(end.In(loc)), User: &user})
+	}
+
+	return entries, nil
+}
+
+// OnCallAt returns the user on call on the schedule at t, computed offline
+// like Render, or nil if nobody is.
+func (s *Schedule) OnCallAt(t time.Time, overrides []Override) (*User, error) {
+	entries, err := s.Render(t, t.Add(time.Nanosecond), overrides)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	return entries[0].User, nil
+}
@@ -0,0 +1,210 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"time"
+)
+
+var _ = Describe("Rotation", func() {
+	var (
+		alice = User{ID: String("PALICE1")}
+		bob   = User{ID: String("PBOB001")}
+		carol = User{ID: String("PCAROL1")}
+
+		// a Monday
+		start = time.Date(2015, time.November, 9, 9, 0, 0, 0, time.UTC)
+		week  = 7 * 24 * time.Hour
+
+		schedule *Schedule
+		entries  []RenderedScheduleEntry
+		err      error
+	)
+
+	entry := func(u User, from, to time.Time) RenderedScheduleEntry {
+		return RenderedScheduleEntry{Start: Time(from), End: Time(to), User: &u}
+	}
+
+	BeforeEach(func() {
+		schedule = &Schedule{
+			ScheduleLayers: []ScheduleLayer{{
+				Start:                     &Date{&start},
+				RotationVirtualStart:      Time(start),
+				RotationTurnLengthSeconds: Int(int(week / time.Second)),
+				Users:                     []User{alice, bob},
+			}},
+		}
+	})
+
+	Describe("Render", func() {
+		Context("with a single weekly rotation", func() {
+			BeforeEach(func() {
+				entries, err = schedule.Render(start.Add(-24*time.Hour), start.Add(3*week), nil)
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should rotate the users from the start of the layer", func() {
+				Expect(entries).To(Equal([]RenderedScheduleEntry{
+					entry(alice, start, start.Add(week)),
+					entry(bob, start.Add(week), start.Add(2*week)),
+					entry(alice, start.Add(2*week), start.Add(3*week)),
+				}))
+			})
+		})
+
+		Context("with a rotation whose virtual start is in the past", func() {
+			BeforeEach(func() {
+				schedule.ScheduleLayers[0].RotationVirtualStart = Time(start.Add(-week))
+				entries, err = schedule.Render(start, start.Add(week), nil)
+			})
+
+			It("should continue the rotation from the virtual start", func() {
+				Expect(entries).To(Equal([]RenderedScheduleEntry{entry(bob, start, start.Add(week))}))
+			})
+		})
+
+		Context("with a restricted layer of higher priority", func() {
+			BeforeEach(func() {
+				schedule.ScheduleLayers = append(schedule.ScheduleLayers, ScheduleLayer{
+					Start:        &Date{&start},
+					Users:        []User{carol},
+					Restrictions: NightRestrictions(TimeOfDay{Hour: 18}, TimeOfDay{Hour: 8}),
+				})
+
+				entries, err = schedule.Render(start, start.Add(24*time.Hour), nil)
+			})
+
+			It("should put the restricted layer on top during its restrictions only", func() {
+				evening := time.Date(2015, time.November, 9, 18, 0, 0, 0, time.UTC)
+				morning := time.Date(2015, time.November, 10, 8, 0, 0, 0, time.UTC)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(Equal([]RenderedScheduleEntry{
+					entry(alice, start, evening),
+					entry(carol, evening, morning),
+					entry(alice, morning, start.Add(24*time.Hour)),
+				}))
+			})
+		})
+
+		Context("with explicit layer priorities", func() {
+			BeforeEach(func() {
+				schedule.ScheduleLayers[0].Priority = Int(2)
+				schedule.ScheduleLayers = append(schedule.ScheduleLayers, ScheduleLayer{
+					Start:    &Date{&start},
+					Priority: Int(1),
+					Users:    []User{carol},
+				})
+
+				entries, err = schedule.Render(start, start.Add(time.Hour), nil)
+			})
+
+			It("should put the layer of highest priority on top", func() {
+				Expect(entries).To(Equal([]RenderedScheduleEntry{entry(alice, start, start.Add(time.Hour))}))
+			})
+		})
+
+		Context("with a layer that ended", func() {
+			BeforeEach(func() {
+				end := start.Add(week + 12*time.Hour)
+				schedule.ScheduleLayers[0].End = &Date{&end}
+
+				entries, err = schedule.Render(start, start.Add(2*week), nil)
+			})
+
+			It("should leave the time after the end uncovered", func() {
+				Expect(entries).To(Equal([]RenderedScheduleEntry{
+					entry(alice, start, start.Add(week)),
+					entry(bob, start.Add(week), start.Add(week+12*time.Hour)),
+				}))
+			})
+		})
+
+		Context("with overrides", func() {
+			BeforeEach(func() {
+				overrides := []Override{
+					{Start: Time(start.Add(time.Hour)), End: Time(start.Add(3 * time.Hour)), User: &carol},
+					{Start: Time(start.Add(2 * time.Hour)), End: Time(start.Add(4 * time.Hour)), User: &bob},
+				}
+
+				entries, err = schedule.Render(start, start.Add(5*time.Hour), overrides)
+			})
+
+			It("should put the overrides on top, the last one winning", func() {
+				Expect(entries).To(Equal([]RenderedScheduleEntry{
+					entry(alice, start, start.Add(time.Hour)),
+					entry(carol, start.Add(time.Hour), start.Add(2*time.Hour)),
+					entry(bob, start.Add(2*time.Hour), start.Add(4*time.Hour)),
+					entry(alice, start.Add(4*time.Hour), start.Add(5*time.Hour)),
+				}))
+			})
+		})
+
+		Context("with a daily rotation across a DST change", func() {
+			var newYork *time.Location
+
+			BeforeEach(func() {
+				newYork, _ = time.LoadLocation("America/New_York")
+				virtualStart := time.Date(2015, time.October, 30, 9, 0, 0, 0, newYork)
+
+				schedule = &Schedule{
+					TimeZone: &TimeZone{newYork},
+					ScheduleLayers: []ScheduleLayer{{
+						RotationVirtualStart:      Time(virtualStart),
+						RotationTurnLengthSeconds: Int(24 * 60 * 60),
+						Users:                     []User{alice, bob},
+					}},
+				}
+
+				entries, err = schedule.Render(virtualStart, virtualStart.AddDate(0, 0, 4), nil)
+			})
+
+			It("should keep the hand-offs at the same wall clock time", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(4))
+				for _, e := range entries {
+					Expect(e.Start.In(newYork).Hour()).To(Equal(9))
+				}
+				Expect(entries[1].End.Sub(*entries[1].Start)).To(Equal(25 * time.Hour))
+			})
+		})
+
+		Context("with a layer rotating several users without a turn length", func() {
+			BeforeEach(func() {
+				schedule.ScheduleLayers[0].RotationTurnLengthSeconds = nil
+				entries, err = schedule.Render(start, start.Add(week), nil)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("with an empty range", func() {
+			It("should return an error", func() {
+				_, err = schedule.Render(start, start, nil)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("OnCallAt", func() {
+		It("should return the user on call at the given time", func() {
+			user, err := schedule.OnCallAt(start.Add(week+3*time.Hour), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*user.ID).To(Equal(*bob.ID))
+		})
+
+		It("should return nil when nobody is on call", func() {
+			user, err := schedule.OnCallAt(start.Add(-time.Hour), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user).To(BeNil())
+		})
+	})
+})
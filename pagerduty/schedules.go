@@ -1,6 +1,8 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -21,23 +23,86 @@ type Schedule struct {
 	ScheduleLayers       []ScheduleLayer    `json:"schedule_layers,omitempty"`
 	OverridesSubschedule *ScheduleLayer     `json:"overrides_subschedule,omitempty"`
 	FinalSchedule        *ScheduleLayer     `json:"final_schedule,omitempty"`
+
+	APIObject
 }
 
 // ScheduleLayer represents one of potentially many layers for a PagerDuty
 // schedule.
 type ScheduleLayer struct {
-	ID                         *string    `json:"id,omitempty"`
-	Name                       *string    `json:"name,omitempty"`
-	Priority                   *int       `json:"priority,omitempty"`
-	Start                      *Date      `json:"start,omitempty"`
-	End                        *Date      `json:"end,omitempty"`
-	Users                      []User     `json:"users,omitempty"`
-	RenderedScheduleEntries    []string   `json:"rendered_schedule_entries,omitempty"`
-	RestrictionType            *string    `json:"restriction_type,omitempty"`
-	Restrictions               []string   `json:"restrictions,omitempty"`
-	RenderedCoveragePercentage *int       `json:"rendered_coverage_percentage,omitempty"`
-	RotationTurnLengthSeconds  *int       `json:"rotation_turn_length_seconds,omitempty"`
-	RotationVirtualStart       *time.Time `json:"rotation_virtual_start,omitempty"`
+	ID                         *string                 `json:"id,omitempty"`
+	Name                       *string                 `json:"name,omitempty"`
+	Priority                   *int                    `json:"priority,omitempty"`
+	Start                      *Date                   `json:"start,omitempty"`
+	End                        *Date                   `json:"end,omitempty"`
+	Users                      []User                  `json:"users,omitempty"`
+	RenderedScheduleEntries    []RenderedScheduleEntry `json:"rendered_schedule_entries,omitempty"`
+	RestrictionType            *string                 `json:"restriction_type,omitempty"`
+	Restrictions               []Restriction           `json:"restrictions,omitempty"`
+	RenderedCoveragePercentage *int                    `json:"rendered_coverage_percentage,omitempty"`
+	RotationTurnLengthSeconds  *int                    `json:"rotation_turn_length_seconds,omitempty"`
+	RotationVirtualStart       *time.Time              `json:"rotation_virtual_start,omitempty"`
+}
+
+// RenderedScheduleEntry is a span of time during which a user is on call in a
+// rendered schedule layer.
+type RenderedScheduleEntry struct {
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+	User  *User      `json:"user,omitempty"`
+}
+
+// scheduleLayerUser is the form in which the API sends and receives the users
+// of a schedule layer.
+type scheduleLayerUser struct {
+	User *User `json:"user"`
+}
+
+func (l ScheduleLayer) MarshalJSON() ([]byte, error) {
+	type alias ScheduleLayer
+
+	var users []scheduleLayerUser
+	for i := range l.Users {
+		users = append(users, scheduleLayerUser{User: &l.Users[i]})
+	}
+
+	return json.Marshal(&struct {
+		alias
+		Users []scheduleLayerUser `json:"users,omitempty"`
+	}{alias(l), users})
+}
+
+func (l *ScheduleLayer) UnmarshalJSON(data []byte) error {
+	type alias ScheduleLayer
+	temp := &struct {
+		*alias
+		Users []json.RawMessage `json:"users,omitempty"`
+	}{alias: (*alias)(l)}
+
+	err := json.Unmarshal(data, temp)
+	if err != nil {
+		return err
+	}
+
+	l.Users = nil
+	for _, raw := range temp.Users {
+		u := new(scheduleLayerUser)
+		if err := json.Unmarshal(raw, u); err != nil {
+			return err
+		}
+
+		if u.User == nil {
+			// a user sent as is rather than wrapped
+			u.User = new(User)
+			if err := json.Unmarshal(raw, u.User); err != nil {
+				return err
+			}
+		}
+
+		l.Users = append(l.Users, *u.User)
+	}
+
+	return nil
 }
 
 type ScheduleListOptions struct {
@@ -61,13 +126,18 @@ type scheduleListWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/list
 func (s *SchedulesService) List(opts *ScheduleListOptions) ([]Schedule, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *SchedulesService) ListContext(ctx context.Context, opts *ScheduleListOptions) ([]Schedule, *Response, error) {
 	uri, err := addOptions("schedules", opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	schedules := new(scheduleListWrapper)
-	resp, err := s.client.Get(uri, schedules)
+	resp, err := s.client.GetContext(ctx, uri, schedules)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -75,6 +145,44 @@ func (s *SchedulesService) List(opts *ScheduleListOptions) ([]Schedule, *Respons
 	return schedules.Schedules, resp, err
 }
 
+// ListAll fetches every schedule matching opts, following the pagination of List
+// until all of them or p.Max of them are collected.
+func (s *SchedulesService) ListAll(opts *ScheduleListOptions, p *PaginationOptions) ([]Schedule, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *SchedulesService) ListAllContext(ctx context.Context, opts *ScheduleListOptions, p *PaginationOptions) ([]Schedule, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every schedule matching opts.
+func (s *SchedulesService) Iter(opts *ScheduleListOptions) *Iterator[Schedule] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *SchedulesService) IterContext(ctx context.Context, opts *ScheduleListOptions) *Iterator[Schedule] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the schedules matching opts.
+func (s *SchedulesService) pager(opts *ScheduleListOptions) (ListOptions, pageFetcher[Schedule]) {
+	var o ScheduleListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]Schedule, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
+
 type scheduleWrapper struct {
 	Schedule *Schedule `json:"schedule"`
 }
@@ -83,10 +191,15 @@ type scheduleWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/show
 func (s *SchedulesService) Get(id string) (*Schedule, *Response, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *SchedulesService) GetContext(ctx context.Context, id string) (*Schedule, *Response, error) {
 	uri := fmt.Sprintf("schedules/%s", id)
 
 	schedule := new(scheduleWrapper)
-	resp, err := s.client.Get(uri, schedule)
+	resp, err := s.client.GetContext(ctx, uri, schedule)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -107,6 +220,11 @@ type ScheduleUsersOptions struct {
 //
 // https://developer.pagerduty.com/documentation/rest/schedules/users
 func (s *SchedulesService) Users(id string, opts *ScheduleUsersOptions) ([]User, *Response, error) {
+	return s.UsersContext(context.Background(), id, opts)
+}
+
+// UsersContext is the context aware variant of Users.
+func (s *SchedulesService) UsersContext(ctx context.Context, id string, opts *ScheduleUsersOptions) ([]User, *Response, error) {
 	path := fmt.Sprintf("schedules/%s/users", id)
 	uri, err := addOptions(path, opts)
 	if err != nil {
@@ -114,10 +232,232 @@ func (s *SchedulesService) Users(id string, opts *ScheduleUsersOptions) ([]User,
 	}
 
 	users := new(userListWrapper)
-	resp, err := s.client.Get(uri, users)
+	resp, err := s.client.GetContext(ctx, uri, users)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return users.Users, resp, err
 }
+
+type ScheduleEntriesOptions struct {
+	// The start of the date range over which you want to return on-call
+	// entries.
+	Since time.Time `url:"since,omitempty"`
+
+	// The end of the date range over which you want to return on-call
+	// entries.
+	Until time.Time `url:"until,omitempty"`
+
+	// Whether on-call entries crossing the range are returned whole rather
+	// than truncated to the range.
+	Overflow bool `url:"overflow,omitempty"`
+
+	// Time zone in which dates in the result will be rendered. Defaults to
+	// the time zone of the schedule.
+	TimeZone *TimeZone `url:"time_zone,omitempty"`
+
+	// Only return the entries of this user.
+	UserID string `url:"user_id,omitempty"`
+}
+
+type scheduleEntryListWrapper struct {
+	Entries []RenderedScheduleEntry `json:"entries"`
+}
+
+// Entries fetches the on-call entries of the final layer of a schedule, i.e.
+// the schedule as rendered with its overrides, filtered by provided options.
+// The REST API v2 has no entries endpoint, so the final schedule is fetched
+// and filtered by user instead.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/entries
+func (s *SchedulesService) Entries(id string, opts *ScheduleEntriesOptions) ([]RenderedScheduleEntry, *Response, error) {
+	return s.EntriesContext(context.Background(), id, opts)
+}
+
+// EntriesContext is the context aware variant of Entries.
+func (s *SchedulesService) EntriesContext(ctx context.Context, id string, opts *ScheduleEntriesOptions) ([]RenderedScheduleEntry, *Response, error) {
+	var o ScheduleEntriesOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	if s.client.isV2() {
+		userID := o.UserID
+		o.UserID = ""
+
+		uri, err := addOptions(fmt.Sprintf("schedules/%s", id), &o)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		schedule := new(scheduleWrapper)
+		resp, err := s.client.GetContext(ctx, uri, schedule)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		if schedule.Schedule == nil || schedule.Schedule.FinalSchedule == nil {
+			return nil, resp, nil
+		}
+
+		var entries []RenderedScheduleEntry
+		for _, e := range schedule.Schedule.FinalSchedule.RenderedScheduleEntries {
+			if userID == "" || (e.User != nil && e.User.ID != nil && *e.User.ID == userID) {
+				entries = append(entries, e)
+			}
+		}
+
+		return entries, resp, nil
+	}
+
+	uri, err := addOptions(fmt.Sprintf("schedules/%s/entries", id), &o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := new(scheduleEntryListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, entries)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return entries.Entries, resp, err
+}
+
+// body returns the request body creating, updating or previewing schedule. The
+// REST API v2 receives the users of the layers as references.
+func (s *SchedulesService) body(schedule *Schedule) *scheduleWrapper {
+	if !s.client.isV2() {
+		return &scheduleWrapper{Schedule: schedule}
+	}
+
+	sch := *schedule
+	sch.ScheduleLayers = make([]ScheduleLayer, len(schedule.ScheduleLayers))
+	for i, layer := range schedule.ScheduleLayers {
+		users := make([]User, len(layer.Users))
+		for j, u := range layer.Users {
+			users[j] = User{ID: u.ID, APIObject: APIObject{Type: String(ObjectTypeUserReference)}}
+		}
+
+		layer.Users = users
+		sch.ScheduleLayers[i] = layer
+	}
+
+	return &scheduleWrapper{Schedule: &sch}
+}
+
+type ScheduleOptions struct {
+	// Whether on-call shifts crossing the rendered range are shown whole
+	// rather than truncated to the range.
+	Overflow bool `url:"overflow,omitempty"`
+}
+
+// Create a schedule with its layers.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/create
+func (s *SchedulesService) Create(schedule *Schedule, opts *ScheduleOptions) (*Schedule, *Response, error) {
+	return s.CreateContext(context.Background(), schedule, opts)
+}
+
+// CreateContext is the context aware variant of Create.
+func (s *SchedulesService) CreateContext(ctx context.Context, schedule *Schedule, opts *ScheduleOptions) (*Schedule, *Response, error) {
+	if schedule == nil {
+		return nil, nil, fmt.Errorf("pagerduty: schedule cannot be nil")
+	}
+
+	uri, err := addOptions("schedules", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sch := new(scheduleWrapper)
+	resp, err := s.client.PostContext(ctx, uri, s.body(schedule), sch)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sch.Schedule, resp, err
+}
+
+// Update an existing schedule. Layers missing from schedule are ended rather
+// than deleted, so that the past of the schedule is kept.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/update
+func (s *SchedulesService) Update(schedule *Schedule, opts *ScheduleOptions) (*Schedule, *Response, error) {
+	return s.UpdateContext(context.Background(), schedule, opts)
+}
+
+// UpdateContext is the context aware variant of Update.
+func (s *SchedulesService) UpdateContext(ctx context.Context, schedule *Schedule, opts *ScheduleOptions) (*Schedule, *Response, error) {
+	if schedule == nil || schedule.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: schedule id cannot be nil")
+	}
+
+	path := fmt.Sprintf("schedules/%s", *schedule.ID)
+	uri, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sch := new(scheduleWrapper)
+	resp, err := s.client.PutContext(ctx, uri, s.body(schedule), sch)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sch.Schedule, resp, err
+}
+
+// Delete a schedule.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/delete
+func (s *SchedulesService) Delete(id string) (*Response, error) {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is the context aware variant of Delete.
+func (s *SchedulesService) DeleteContext(ctx context.Context, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("schedules/%s", id))
+}
+
+type SchedulePreviewOptions struct {
+	// The start of the date range to render the schedule over.
+	Since time.Time `url:"since,omitempty"`
+
+	// The end of the date range to render the schedule over.
+	Until time.Time `url:"until,omitempty"`
+
+	// Whether on-call shifts crossing the range are shown whole rather than
+	// truncated to the range.
+	Overflow bool `url:"overflow,omitempty"`
+}
+
+// Preview renders a proposed schedule over a date range without saving it.
+// The rendered entries of each layer and of the final schedule are returned
+// in the RenderedScheduleEntries of the returned schedule.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/schedules/preview
+func (s *SchedulesService) Preview(schedule *Schedule, opts *SchedulePreviewOptions) (*Schedule, *Response, error) {
+	return s.PreviewContext(context.Background(), schedule, opts)
+}
+
+// PreviewContext is the context aware variant of Preview.
+func (s *SchedulesService) PreviewContext(ctx context.Context, schedule *Schedule, opts *SchedulePreviewOptions) (*Schedule, *Response, error) {
+	if schedule == nil {
+		return nil, nil, fmt.Errorf("pagerduty: schedule cannot be nil")
+	}
+
+	uri, err := addOptions("schedules/preview", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sch := new(scheduleWrapper)
+	resp, err := s.client.PostContext(ctx, uri, s.body(schedule), sch)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sch.Schedule, resp, err
+}
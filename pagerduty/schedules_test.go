@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"time"
 )
 
 const (
@@ -23,6 +24,37 @@ const (
 		"today": "2006-01-02",
 		"escalation_policies": []
 	}`
+	schedulePreviewJSON = `{ "schedule": {
+		"name": "Primary",
+		"time_zone": "UTC",
+		"schedule_layers": [{
+			"name": "Weekly",
+			"start": "2015-11-06T20:00:00Z",
+			"rotation_virtual_start": "2015-11-06T20:00:00Z",
+			"rotation_turn_length_seconds": 604800,
+			"users": [
+				{ "user": { "id": "PXPGF42", "name": "Earline Greenholt" } },
+				{ "user": { "id": "PT23IWX", "name": "Tim Wright" } }
+			],
+			"rendered_coverage_percentage": 100
+		}],
+		"final_schedule": {
+			"name": "Final Schedule",
+			"rendered_schedule_entries": [
+				{
+					"start": "2015-11-06T20:00:00Z",
+					"end": "2015-11-13T20:00:00Z",
+					"user": { "id": "PXPGF42", "name": "Earline Greenholt" }
+				},
+				{
+					"start": "2015-11-13T20:00:00Z",
+					"end": "2015-11-20T20:00:00Z",
+					"user": { "id": "PT23IWX", "name": "Tim Wright" }
+				}
+			],
+			"rendered_coverage_percentage": 100
+		}
+	}}`
 )
 
 var _ = Describe("Schedules", func() {
@@ -148,4 +180,246 @@ var _ = Describe("Schedules", func() {
 			})
 		})
 	})
+
+	Describe("Create", func() {
+		var (
+			schedule *Schedule
+			start    = time.Date(2015, time.November, 6, 20, 0, 0, 0, time.UTC)
+		)
+
+		newSchedule := func() *Schedule {
+			return &Schedule{
+				Name: String("Primary"),
+				ScheduleLayers: []ScheduleLayer{{
+					Name:                      String("Weekly"),
+					Start:                     &Date{&start},
+					RotationVirtualStart:      Time(start),
+					RotationTurnLengthSeconds: Int(604800),
+					Users:                     []User{{ID: String("PXPGF42")}, {ID: String("PT23IWX")}},
+				}},
+			}
+		}
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/schedules", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"schedule": {
+							"name": "Primary",
+							"schedule_layers": [{
+								"name": "Weekly",
+								"start": "2015-11-06T20:00:00Z",
+								"rotation_virtual_start": "2015-11-06T20:00:00Z",
+								"rotation_turn_length_seconds": 604800,
+								"users": [
+									{ "user": { "id": "PXPGF42" } },
+									{ "user": { "id": "PT23IWX" } }
+								]
+							}]
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, scheduleGetJSON),
+				))
+
+				schedule, resp, err = env.Client.Schedules.Create(newSchedule(), nil)
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the created schedule", func() {
+				Expect(schedule).To(Equal(&expectedSchedule))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/schedules", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"schedule": {
+							"name": "Primary",
+							"schedule_layers": [{
+								"name": "Weekly",
+								"start": "2015-11-06T20:00:00Z",
+								"rotation_virtual_start": "2015-11-06T20:00:00Z",
+								"rotation_turn_length_seconds": 604800,
+								"users": [
+									{ "user": { "id": "PXPGF42", "type": "user_reference" } },
+									{ "user": { "id": "PT23IWX", "type": "user_reference" } }
+								]
+							}]
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, scheduleGetJSON),
+				))
+
+				schedule, resp, err = env.Client.Schedules.Create(newSchedule(), nil)
+			})
+
+			It("should send the users as references", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(schedule).To(Equal(&expectedSchedule))
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		Context("with a successful, non-empty response", func() {
+			var schedule *Schedule
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/schedules/id", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyURLQueryHandler(url.Values{"overflow": []string{"true"}}),
+					ghttp.VerifyJSON(`{ "schedule": { "id": "id", "name": "name" } }`),
+					ghttp.RespondWith(http.StatusOK, scheduleGetJSON),
+				))
+
+				schedule, resp, err = env.Client.Schedules.Update(&Schedule{
+					ID:   String("id"),
+					Name: String("name"),
+				}, &ScheduleOptions{Overflow: true})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the updated schedule", func() {
+				Expect(schedule).To(Equal(&expectedSchedule))
+			})
+		})
+
+		Context("without an id", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Schedules.Update(&Schedule{}, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete the schedule", func() {
+			env.Server.RouteToHandler(DELETE, "/schedules/id", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err = env.Client.Schedules.Delete("id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("Preview", func() {
+		Context("with a valid schedule and options", func() {
+			var schedule *Schedule
+
+			BeforeEach(func() {
+				values := make(url.Values)
+				values.Add("since", testTimeString)
+				values.Add("until", testTimeString)
+
+				env.Server.RouteToHandler(POST, "/schedules/preview", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyURLQueryHandler(values),
+					ghttp.RespondWith(http.StatusOK, schedulePreviewJSON),
+				))
+
+				schedule, resp, err = env.Client.Schedules.Preview(&Schedule{Name: String("Primary")}, &SchedulePreviewOptions{
+					Since: testTime,
+					Until: testTime,
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should decode the layers of the schedule", func() {
+				layer := schedule.ScheduleLayers[0]
+				Expect(layer.Start.Equal(time.Date(2015, time.November, 6, 20, 0, 0, 0, time.UTC))).To(BeTrue())
+				Expect(layer.Users).To(HaveLen(2))
+				Expect(*layer.Users[1].ID).To(Equal("PT23IWX"))
+			})
+
+			It("should return the rendered entries of the final schedule", func() {
+				entries := schedule.FinalSchedule.RenderedScheduleEntries
+				Expect(entries).To(HaveLen(2))
+				Expect(*entries[0].User.ID).To(Equal("PXPGF42"))
+				Expect(entries[0].End.Sub(*entries[0].Start)).To(Equal(7 * 24 * time.Hour))
+			})
+		})
+	})
+
+	Describe("Entries", func() {
+		var entries []RenderedScheduleEntry
+
+		Context("with the REST API v1", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/schedules/id/entries", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyURLQueryHandler(url.Values{"user_id": []string{"PT23IWX"}}),
+					ghttp.RespondWith(http.StatusOK, `{ "entries": [{
+						"start": "2015-11-13T20:00:00Z",
+						"end": "2015-11-20T20:00:00Z",
+						"user": { "id": "PT23IWX", "name": "Tim Wright" }
+					}]}`),
+				))
+
+				entries, resp, err = env.Client.Schedules.Entries("id", &ScheduleEntriesOptions{UserID: "PT23IWX"})
+			})
+
+			It("should return the entries of the schedule", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(*entries[0].User.ID).To(Equal("PT23IWX"))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(GET, "/schedules/id", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					verifyURLQueryHandler(url.Values{}),
+					ghttp.RespondWith(http.StatusOK, schedulePreviewJSON),
+				))
+
+				entries, resp, err = env.Client.Schedules.Entries("id", &ScheduleEntriesOptions{UserID: "PT23IWX"})
+			})
+
+			It("should filter the final schedule by user", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(*entries[0].User.ID).To(Equal("PT23IWX"))
+			})
+		})
+	})
 })
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -47,6 +48,9 @@ type Service struct {
 	EscalationPolicy       *EscalationPolicy `json:"escalation_policy,omitempty"`
 	EmailFilters           []EmailFilter     `json:"email_filters,omitempty"`
 	SeverityFilter         *string           `json:"severity_filter,omitempty"`
+	Integrations           []Integration     `json:"integrations,omitempty"`
+
+	APIObject
 }
 
 type IncidentCounts struct {
@@ -79,6 +83,10 @@ type ServiceListOptions struct {
 	// fields are 'name' and 'id'.
 	SortBy string `url:"sort_by,omitempty"`
 
+	// REST API v2 filter, which replaces the comma-separated Teams filter of
+	// the REST API v1.
+	TeamIDs []string `url:"team_ids[],omitempty"`
+
 	ListOptions
 }
 
@@ -90,13 +98,18 @@ type serviceListWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/list
 func (s *ServicesService) List(opts *ServiceListOptions) ([]Service, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *ServicesService) ListContext(ctx context.Context, opts *ServiceListOptions) ([]Service, *Response, error) {
 	uri, err := addOptions("services", opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	services := new(serviceListWrapper)
-	resp, err := s.client.Get(uri, services)
+	resp, err := s.client.GetContext(ctx, uri, services)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -104,6 +117,48 @@ func (s *ServicesService) List(opts *ServiceListOptions) ([]Service, *Response,
 	return services.Services, resp, err
 }
 
+// ListAll fetches every service matching opts, following the pagination of List
+// until all of them or p.Max of them are collected.
+func (s *ServicesService) ListAll(opts *ServiceListOptions, p *PaginationOptions) ([]Service, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *ServicesService) ListAllContext(ctx context.Context, opts *ServiceListOptions, p *PaginationOptions) ([]Service, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every service matching opts.
+func (s *ServicesService) Iter(opts *ServiceListOptions) *Iterator[Service] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *ServicesService) IterContext(ctx context.Context, opts *ServiceListOptions) *Iterator[Service] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the services matching opts.
+func (s *ServicesService) pager(opts *ServiceListOptions) (ListOptions, pageFetcher[Service]) {
+	var o ServiceListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]Service, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
+
+type serviceWrapper struct {
+	Service *Service `json:"service"`
+}
+
 type GetServiceOptions struct {
 	// Include extra information in the response. Possible values are
 	// 'escalation_policy' and 'email_filters'.
@@ -114,17 +169,201 @@ type GetServiceOptions struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/show
 func (s *ServicesService) Get(id string, opts *GetServiceOptions) (*Service, *Response, error) {
+	return s.GetContext(context.Background(), id, opts)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *ServicesService) GetContext(ctx context.Context, id string, opts *GetServiceOptions) (*Service, *Response, error) {
 	path := fmt.Sprintf("services/%s", id)
 	uri, err := addOptions(path, opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if s.client.isV2() {
+		service := new(serviceWrapper)
+		resp, err := s.client.GetContext(ctx, uri, service)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		return service.Service, resp, err
+	}
+
 	service := new(Service)
-	resp, err := s.client.Get(uri, service)
+	resp, err := s.client.GetContext(ctx, uri, service)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return service, resp, err
 }
+
+// serviceV1 is the body of the REST API v1 requests creating or updating a
+// service, which take the id of the escalation policy instead of an object.
+type serviceV1 struct {
+	*Service
+	EscalationPolicyID *string `json:"escalation_policy_id,omitempty"`
+}
+
+// body returns the request body creating or updating service.
+func (s *ServicesService) body(service *Service) interface{} {
+	svc := *service
+	if svc.EscalationPolicy == nil || svc.EscalationPolicy.ID == nil {
+		return &serviceWrapper{Service: &svc}
+	}
+
+	if !s.client.isV2() {
+		policyID := svc.EscalationPolicy.ID
+		svc.EscalationPolicy = nil
+		return &struct {
+			Service *serviceV1 `json:"service"`
+		}{&serviceV1{Service: &svc, EscalationPolicyID: policyID}}
+	}
+
+	if svc.EscalationPolicy.Type == nil {
+		svc.EscalationPolicy = &EscalationPolicy{
+			ID:        svc.EscalationPolicy.ID,
+			APIObject: APIObject{Type: String(ObjectTypeEscalationPolicyReference)},
+		}
+	}
+
+	return &serviceWrapper{Service: &svc}
+}
+
+// Create a service using the escalation policy in service.EscalationPolicy,
+// which only needs its ID set.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/create
+func (s *ServicesService) Create(service *Service) (*Service, *Response, error) {
+	return s.CreateContext(context.Background(), service)
+}
+
+// CreateContext is the context aware variant of Create.
+func (s *ServicesService) CreateContext(ctx context.Context, service *Service) (*Service, *Response, error) {
+	if service == nil {
+		return nil, nil, fmt.Errorf("pagerduty: service cannot be nil")
+	}
+
+	uri := "services"
+
+	svc := new(serviceWrapper)
+	resp, err := s.client.PostContext(ctx, uri, s.body(service), svc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return svc.Service, resp, err
+}
+
+// Update an existing service.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/update
+func (s *ServicesService) Update(service *Service) (*Service, *Response, error) {
+	return s.UpdateContext(context.Background(), service)
+}
+
+// UpdateContext is the context aware variant of Update.
+func (s *ServicesService) UpdateContext(ctx context.Context, service *Service) (*Service, *Response, error) {
+	if service == nil || service.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: service id cannot be nil")
+	}
+
+	uri := fmt.Sprintf("services/%s", *service.ID)
+
+	svc := new(serviceWrapper)
+	resp, err := s.client.PutContext(ctx, uri, s.body(service), svc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return svc.Service, resp, err
+}
+
+// Delete a service. Its incidents are deleted along with it.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/delete
+func (s *ServicesService) Delete(id string) (*Response, error) {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is the context aware variant of Delete.
+func (s *ServicesService) DeleteContext(ctx context.Context, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("services/%s", id))
+}
+
+type serviceStatusOptions struct {
+	RequesterID string `url:"requester_id,omitempty"`
+}
+
+// Enable a disabled service. requesterID is the id of the user enabling the
+// service. It may be empty for a client speaking the REST API v2, which
+// identifies the requester through Client.From instead.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/enable
+func (s *ServicesService) Enable(id, requesterID string) (*Response, error) {
+	return s.EnableContext(context.Background(), id, requesterID)
+}
+
+// EnableContext is the context aware variant of Enable.
+func (s *ServicesService) EnableContext(ctx context.Context, id, requesterID string) (*Response, error) {
+	return s.setStatus(ctx, id, requesterID, "enable", StatusActive)
+}
+
+// Disable a service, so that it no longer creates incidents. requesterID is
+// the id of the user disabling the service. It may be empty for a client
+// speaking the REST API v2, which identifies the requester through
+// Client.From instead.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/disable
+func (s *ServicesService) Disable(id, requesterID string) (*Response, error) {
+	return s.DisableContext(context.Background(), id, requesterID)
+}
+
+// DisableContext is the context aware variant of Disable.
+func (s *ServicesService) DisableContext(ctx context.Context, id, requesterID string) (*Response, error) {
+	return s.setStatus(ctx, id, requesterID, "disable", StatusDisabled)
+}
+
+// setStatus enables or disables a service, through the action endpoint of the
+// REST API v1 or by updating the status of the service in the REST API v2.
+func (s *ServicesService) setStatus(ctx context.Context, id, requesterID, action, status string) (*Response, error) {
+	if s.client.isV2() {
+		_, resp, err := s.UpdateContext(ctx, &Service{ID: String(id), Status: String(status)})
+		return resp, err
+	}
+
+	path := fmt.Sprintf("services/%s/%s", id, action)
+	uri, err := addOptions(path, &serviceStatusOptions{RequesterID: requesterID})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.PutContext(ctx, uri, nil, nil)
+}
+
+// RegenerateKey regenerates the service key of a service, invalidating the
+// previous one. The REST API v2 has no equivalent: integration keys replace
+// service keys there.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/services/regenerate_key
+func (s *ServicesService) RegenerateKey(id string) (*Service, *Response, error) {
+	return s.RegenerateKeyContext(context.Background(), id)
+}
+
+// RegenerateKeyContext is the context aware variant of RegenerateKey.
+func (s *ServicesService) RegenerateKeyContext(ctx context.Context, id string) (*Service, *Response, error) {
+	if s.client.isV2() {
+		return nil, nil, errUnsupportedV2("Services.RegenerateKey")
+	}
+
+	uri := fmt.Sprintf("services/%s/regenerate_key", id)
+
+	svc := new(serviceWrapper)
+	resp, err := s.client.PostContext(ctx, uri, nil, svc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return svc.Service, resp, err
+}
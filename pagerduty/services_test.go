@@ -9,11 +9,13 @@ import (
 
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"regexp"
 )
 
 const (
 	serviceListJSON = `{ "services": [` + serviceJSON + `]}`
+	serviceGetJSON  = `{ "service": ` + serviceJSON + `}`
 	serviceJSON     = `{
 		"id": "id",
 		"name": "name",
@@ -121,4 +123,211 @@ var _ = Describe("Services", func() {
 			})
 		})
 	})
+
+	Describe("Create", func() {
+		var (
+			service *Service
+			resp    *Response
+			err     error
+		)
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/services", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"service": {
+							"name": "name",
+							"escalation_policy_id": "PWIP6CQ"
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, serviceGetJSON),
+				))
+
+				service, resp, err = env.Client.Services.Create(&Service{
+					Name:             String("name"),
+					EscalationPolicy: &EscalationPolicy{ID: String("PWIP6CQ")},
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the created service", func() {
+				Expect(service).To(Equal(&expectedService))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/services", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"service": {
+							"name": "name",
+							"escalation_policy": {
+								"id": "PWIP6CQ",
+								"type": "escalation_policy_reference"
+							}
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, serviceGetJSON),
+				))
+
+				service, resp, err = env.Client.Services.Create(&Service{
+					Name:             String("name"),
+					EscalationPolicy: &EscalationPolicy{ID: String("PWIP6CQ")},
+				})
+			})
+
+			It("should reference the escalation policy", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(service).To(Equal(&expectedService))
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		Context("with a successful, non-empty response", func() {
+			var (
+				service *Service
+				err     error
+			)
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/services/id", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.VerifyJSON(`{ "service": { "id": "id", "description": "description" } }`),
+					ghttp.RespondWith(http.StatusOK, serviceGetJSON),
+				))
+
+				service, _, err = env.Client.Services.Update(&Service{
+					ID:          String("id"),
+					Description: String("description"),
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return the updated service", func() {
+				Expect(service).To(Equal(&expectedService))
+			})
+		})
+
+		Context("without an id", func() {
+			It("should return an error without making a request", func() {
+				_, _, err := env.Client.Services.Update(&Service{})
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete the service", func() {
+			env.Server.RouteToHandler(DELETE, "/services/id", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.Services.Delete("id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("Disable", func() {
+		var err error
+
+		Context("with the REST API v1", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/services/id/disable", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyURLQueryHandler(url.Values{"requester_id": []string{"PT23IWX"}}),
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+
+				_, err = env.Client.Services.Disable("id", "PT23IWX")
+			})
+
+			It("should call the disable action", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(PUT, "/services/id", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{ "service": { "id": "id", "status": "disabled" } }`),
+					ghttp.RespondWith(http.StatusOK, serviceGetJSON),
+				))
+
+				_, err = env.Client.Services.Disable("id", "")
+			})
+
+			It("should update the status of the service", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("Enable", func() {
+		It("should call the enable action", func() {
+			env.Server.RouteToHandler(PUT, "/services/id/enable", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+
+			_, err := env.Client.Services.Enable("id", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("RegenerateKey", func() {
+		It("should return the service with its new key", func() {
+			env.Server.RouteToHandler(POST, "/services/id/regenerate_key", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusCreated, serviceGetJSON),
+			))
+
+			service, _, err := env.Client.Services.RegenerateKey("id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service).To(Equal(&expectedService))
+		})
+
+		It("should not be supported by the REST API v2", func() {
+			env.Server.Close()
+			env = NewTestEnvironmentV2()
+
+			_, _, err := env.Client.Services.RegenerateKey("id")
+			Expect(err).To(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
 })
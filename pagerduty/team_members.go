@@ -0,0 +1,166 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+)
+
+// Roles of the users of a team in the REST API v2.
+const (
+	TeamRoleObserver  = "observer"
+	TeamRoleResponder = "responder"
+	TeamRoleManager   = "manager"
+)
+
+// TeamMember is a user of a team along with their role in it.
+type TeamMember struct {
+	User *User   `json:"user,omitempty"`
+	Role *string `json:"role,omitempty"`
+}
+
+type TeamMemberListOptions struct {
+	ListOptions
+}
+
+type teamMemberListWrapper struct {
+	Members []TeamMember `json:"members"`
+}
+
+type teamRoleBody struct {
+	Role string `json:"role"`
+}
+
+// AddUser adds a user to a team with the given role, or updates their role if
+// they already are a member. The REST API v1 has no team roles, so role must
+// be empty for a client speaking it.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/add_user
+func (s *TeamsService) AddUser(teamID, userID, role string) (*Response, error) {
+	return s.AddUserContext(context.Background(), teamID, userID, role)
+}
+
+// AddUserContext is the context aware variant of AddUser.
+func (s *TeamsService) AddUserContext(ctx context.Context, teamID, userID, role string) (*Response, error) {
+	uri := fmt.Sprintf("teams/%s/users/%s", teamID, userID)
+
+	if role == "" {
+		return s.client.PutContext(ctx, uri, nil, nil)
+	}
+	if !s.client.isV2() {
+		return nil, errRequiresV2("Teams.AddUser with a role")
+	}
+
+	return s.client.PutContext(ctx, uri, &teamRoleBody{Role: role}, nil)
+}
+
+// RemoveUser removes a user from a team.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/remove_user
+func (s *TeamsService) RemoveUser(teamID, userID string) (*Response, error) {
+	return s.RemoveUserContext(context.Background(), teamID, userID)
+}
+
+// RemoveUserContext is the context aware variant of RemoveUser.
+func (s *TeamsService) RemoveUserContext(ctx context.Context, teamID, userID string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("teams/%s/users/%s", teamID, userID))
+}
+
+// AddEscalationPolicy adds an escalation policy to a team.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/add_escalation_policy
+func (s *TeamsService) AddEscalationPolicy(teamID, policyID string) (*Response, error) {
+	return s.AddEscalationPolicyContext(context.Background(), teamID, policyID)
+}
+
+// AddEscalationPolicyContext is the context aware variant of
+// AddEscalationPolicy.
+func (s *TeamsService) AddEscalationPolicyContext(ctx context.Context, teamID, policyID string) (*Response, error) {
+	return s.client.PutContext(ctx, fmt.Sprintf("teams/%s/escalation_policies/%s", teamID, policyID), nil, nil)
+}
+
+// RemoveEscalationPolicy removes an escalation policy from a team.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/remove_escalation_policy
+func (s *TeamsService) RemoveEscalationPolicy(teamID, policyID string) (*Response, error) {
+	return s.RemoveEscalationPolicyContext(context.Background(), teamID, policyID)
+}
+
+// RemoveEscalationPolicyContext is the context aware variant of
+// RemoveEscalationPolicy.
+func (s *TeamsService) RemoveEscalationPolicyContext(ctx context.Context, teamID, policyID string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("teams/%s/escalation_policies/%s", teamID, policyID))
+}
+
+// ListMembers lists the users of a team along with their roles. It requires
+// a client speaking the REST API v2.
+//
+// PagerDuty API docs: https://v2.developer.pagerduty.com/v2/page/api-reference#!/Teams/get_teams_id_members
+func (s *TeamsService) ListMembers(teamID string, opts *TeamMemberListOptions) ([]TeamMember, *Response, error) {
+	return s.ListMembersContext(context.Background(), teamID, opts)
+}
+
+// ListMembersContext is the context aware variant of ListMembers.
+func (s *TeamsService) ListMembersContext(ctx context.Context, teamID string, opts *TeamMemberListOptions) ([]TeamMember, *Response, error) {
+	if !s.client.isV2() {
+		return nil, nil, errRequiresV2("Teams.ListMembers")
+	}
+
+	uri, err := addOptions(fmt.Sprintf("teams/%s/members", teamID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := new(teamMemberListWrapper)
+	resp, err := s.client.GetContext(ctx, uri, members)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return members.Members, resp, err
+}
+
+// ListAllMembers fetches every member of a team, following the pagination of
+// ListMembers until all of them or p.Max of them are collected.
+func (s *TeamsService) ListAllMembers(teamID string, opts *TeamMemberListOptions, p *PaginationOptions) ([]TeamMember, error) {
+	return s.ListAllMembersContext(context.Background(), teamID, opts, p)
+}
+
+// ListAllMembersContext is the context aware variant of ListAllMembers.
+func (s *TeamsService) ListAllMembersContext(ctx context.Context, teamID string, opts *TeamMemberListOptions, p *PaginationOptions) ([]TeamMember, error) {
+	var o TeamMemberListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return listAll(ctx, func(ctx context.Context, page ListOptions) ([]TeamMember, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListMembersContext(ctx, teamID, &o)
+	}, o.ListOptions, p)
+}
+
+// GetWithMembers fetches a team by id along with all of its members. It
+// requires a client speaking the REST API v2.
+func (s *TeamsService) GetWithMembers(id string) (*Team, *Response, error) {
+	return s.GetWithMembersContext(context.Background(), id)
+}
+
+// GetWithMembersContext is the context aware variant of GetWithMembers.
+func (s *TeamsService) GetWithMembersContext(ctx context.Context, id string) (*Team, *Response, error) {
+	if !s.client.isV2() {
+		return nil, nil, errRequiresV2("Teams.GetWithMembers")
+	}
+
+	team, resp, err := s.GetContext(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	members, err := s.ListAllMembersContext(ctx, id, nil, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	team.Members = members
+
+	return team, resp, nil
+}
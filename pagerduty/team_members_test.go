@@ -0,0 +1,213 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+const (
+	teamMemberListJSON = `{
+		"members": [` + teamMemberJSON + `],
+		"limit": 25,
+		"offset": 0,
+		"more": false
+	}`
+	teamMemberJSON = `{
+		"user": {
+			"id": "PXPGF42",
+			"type": "user_reference",
+			"summary": "Earline Greenholt"
+		},
+		"role": "manager"
+	}`
+)
+
+var _ = Describe("TeamMembers", func() {
+	var (
+		env                *TestEnvironment
+		expectedTeamMember TeamMember
+	)
+
+	json.Unmarshal([]byte(teamMemberJSON), &expectedTeamMember)
+
+	BeforeEach(func() { env = NewTestEnvironmentV2() })
+	AfterEach(func() { env.Server.Close() })
+
+	Describe("AddUser", func() {
+		var err error
+
+		Context("with a role", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/teams/PQ9K7I8/users/PXPGF42", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{ "role": "responder" }`),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				))
+
+				_, err = env.Client.Teams.AddUser("PQ9K7I8", "PXPGF42", TeamRoleResponder)
+			})
+
+			It("should add the user with the role", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("with the REST API v1", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironment()
+			})
+
+			It("should add the user without a body", func() {
+				env.Server.RouteToHandler(PUT, "/teams/PQ9K7I8/users/PXPGF42", ghttp.CombineHandlers(
+					verifyAuthorizationHeaderHandler,
+					ghttp.VerifyBody([]byte{}),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				))
+
+				_, err = env.Client.Teams.AddUser("PQ9K7I8", "PXPGF42", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should return an error without making a request when given a role", func() {
+				_, err = env.Client.Teams.AddUser("PQ9K7I8", "PXPGF42", TeamRoleManager)
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("RemoveUser", func() {
+		It("should remove the user", func() {
+			env.Server.RouteToHandler(DELETE, "/teams/PQ9K7I8/users/PXPGF42", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.Teams.RemoveUser("PQ9K7I8", "PXPGF42")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("AddEscalationPolicy", func() {
+		It("should add the escalation policy", func() {
+			env.Server.RouteToHandler(PUT, "/teams/PQ9K7I8/escalation_policies/PANZZEQ", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.Teams.AddEscalationPolicy("PQ9K7I8", "PANZZEQ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("RemoveEscalationPolicy", func() {
+		It("should remove the escalation policy", func() {
+			env.Server.RouteToHandler(DELETE, "/teams/PQ9K7I8/escalation_policies/PANZZEQ", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.Teams.RemoveEscalationPolicy("PQ9K7I8", "PANZZEQ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("ListMembers", func() {
+		Context("with a successful, non-empty response", func() {
+			var (
+				members []TeamMember
+				resp    *Response
+				err     error
+			)
+
+			BeforeEach(func() {
+				env.Server.RouteToHandler(GET, "/teams/PQ9K7I8/members", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					verifyURLQueryHandler(url.Values{"limit": []string{"25"}}),
+					ghttp.RespondWith(http.StatusOK, teamMemberListJSON),
+				))
+
+				members, resp, err = env.Client.Teams.ListMembers("PQ9K7I8", &TeamMemberListOptions{
+					ListOptions: ListOptions{Limit: 25},
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the expected members", func() {
+				Expect(members).To(Equal([]TeamMember{expectedTeamMember}))
+				Expect(*members[0].Role).To(Equal(TeamRoleManager))
+			})
+		})
+
+		Context("with the REST API v1", func() {
+			It("should return an error without making a request", func() {
+				env.Server.Close()
+				env = NewTestEnvironment()
+
+				_, _, err := env.Client.Teams.ListMembers("PQ9K7I8", nil)
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetWithMembers", func() {
+		It("should return the team along with its members", func() {
+			env.Server.RouteToHandler(GET, "/teams/PQ9K7I8", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.RespondWith(http.StatusOK, teamGetJSON),
+			))
+			env.Server.RouteToHandler(GET, "/teams/PQ9K7I8/members", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.RespondWith(http.StatusOK, teamMemberListJSON),
+			))
+
+			team, _, err := env.Client.Teams.GetWithMembers("PQ9K7I8")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*team.Name).To(Equal("name"))
+			Expect(team.Members).To(Equal([]TeamMember{expectedTeamMember}))
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	Describe("Edit", func() {
+		It("should not send the members of the team", func() {
+			env.Server.RouteToHandler(PUT, "/teams/PQ9K7I8", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.VerifyJSON(`{ "team": { "id": "PQ9K7I8", "name": "name" } }`),
+				ghttp.RespondWith(http.StatusOK, teamGetJSON),
+			))
+
+			_, _, err := env.Client.Teams.Edit(&Team{
+				ID:      String("PQ9K7I8"),
+				Name:    String("name"),
+				Members: []TeamMember{expectedTeamMember},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
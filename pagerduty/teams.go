@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -15,6 +16,11 @@ type Team struct {
 	ID          *string `json:"id,omitempty"`
 	Name        *string `json:"name,omitempty"`
 	Description *string `json:"description,omitempty"`
+
+	// The members of the team, only set by GetWithMembers.
+	Members []TeamMember `json:"members,omitempty"`
+
+	APIObject
 }
 
 type TeamListOptions struct {
@@ -32,13 +38,18 @@ type teamListWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/list
 func (s *TeamsService) List(opts *TeamListOptions) ([]Team, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *TeamsService) ListContext(ctx context.Context, opts *TeamListOptions) ([]Team, *Response, error) {
 	uri, err := addOptions("teams", opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	teams := new(teamListWrapper)
-	resp, err := s.client.Get(uri, teams)
+	resp, err := s.client.GetContext(ctx, uri, teams)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -46,6 +57,44 @@ func (s *TeamsService) List(opts *TeamListOptions) ([]Team, *Response, error) {
 	return teams.Teams, resp, err
 }
 
+// ListAll fetches every team matching opts, following the pagination of List
+// until all of them or p.Max of them are collected.
+func (s *TeamsService) ListAll(opts *TeamListOptions, p *PaginationOptions) ([]Team, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *TeamsService) ListAllContext(ctx context.Context, opts *TeamListOptions, p *PaginationOptions) ([]Team, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every team matching opts.
+func (s *TeamsService) Iter(opts *TeamListOptions) *Iterator[Team] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *TeamsService) IterContext(ctx context.Context, opts *TeamListOptions) *Iterator[Team] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the teams matching opts.
+func (s *TeamsService) pager(opts *TeamListOptions) (ListOptions, pageFetcher[Team]) {
+	var o TeamListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]Team, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
+
 type teamWrapper struct {
 	Team *Team `json:"team"`
 }
@@ -54,10 +103,15 @@ type teamWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/show
 func (s *TeamsService) Get(id string) (*Team, *Response, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *TeamsService) GetContext(ctx context.Context, id string) (*Team, *Response, error) {
 	uri := fmt.Sprintf("teams/%s", id)
 
 	team := new(teamWrapper)
-	resp, err := s.client.Get(uri, team)
+	resp, err := s.client.GetContext(ctx, uri, team)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -65,14 +119,42 @@ func (s *TeamsService) Get(id string) (*Team, *Response, error) {
 	return team.Team, resp, err
 }
 
+// teamBody returns the team sent creating or updating team, without its
+// members, which are managed on their own.
+func teamBody(team *Team) *Team {
+	if team == nil || team.Members == nil {
+		return team
+	}
+
+	t := *team
+	t.Members = nil
+	return &t
+}
+
 // Create a team.
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/create
 func (s *TeamsService) Create(team *Team) (*Team, *Response, error) {
+	return s.CreateContext(context.Background(), team)
+}
+
+// CreateContext is the context aware variant of Create.
+func (s *TeamsService) CreateContext(ctx context.Context, team *Team) (*Team, *Response, error) {
 	uri := "teams"
+	team = teamBody(team)
+
+	if s.client.isV2() {
+		t := new(teamWrapper)
+		resp, err := s.client.PostContext(ctx, uri, &teamWrapper{Team: team}, t)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		return t.Team, resp, err
+	}
 
 	t := new(Team)
-	resp, err := s.client.Post(uri, team, t)
+	resp, err := s.client.PostContext(ctx, uri, team, t)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -84,10 +166,30 @@ func (s *TeamsService) Create(team *Team) (*Team, *Response, error) {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/update
 func (s *TeamsService) Edit(team *Team) (*Team, *Response, error) {
-	uri := fmt.Sprintf("teams/%s", team.ID)
+	return s.EditContext(context.Background(), team)
+}
+
+// EditContext is the context aware variant of Edit.
+func (s *TeamsService) EditContext(ctx context.Context, team *Team) (*Team, *Response, error) {
+	if team == nil || team.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: team id cannot be nil")
+	}
+
+	uri := fmt.Sprintf("teams/%s", *team.ID)
+	team = teamBody(team)
+
+	if s.client.isV2() {
+		t := new(teamWrapper)
+		resp, err := s.client.PutContext(ctx, uri, &teamWrapper{Team: team}, t)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		return t.Team, resp, err
+	}
 
 	t := new(Team)
-	resp, err := s.client.Put(uri, team, t)
+	resp, err := s.client.PutContext(ctx, uri, team, t)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -99,5 +201,10 @@ func (s *TeamsService) Edit(team *Team) (*Team, *Response, error) {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/teams/delete
 func (s *TeamsService) Delete(id string) (*Response, error) {
-	return s.client.Delete(fmt.Sprintf("teams/%s", id))
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is the context aware variant of Delete.
+func (s *TeamsService) DeleteContext(ctx context.Context, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("teams/%s", id))
 }
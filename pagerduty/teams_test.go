@@ -106,4 +106,32 @@ var _ = Describe("Teams", func() {
 			})
 		})
 	})
+
+	Describe("Create with the REST API v2", func() {
+		var (
+			team *Team
+			err  error
+		)
+
+		BeforeEach(func() {
+			env.Server.Close()
+			env = NewTestEnvironmentV2()
+
+			env.Server.RouteToHandler(POST, "/teams", ghttp.CombineHandlers(
+				verifyV2HeaderHandler,
+				ghttp.VerifyJSON(`{ "team": { "name": "name", "type": "team" } }`),
+				ghttp.RespondWith(http.StatusCreated, teamGetJSON),
+			))
+
+			team, _, err = env.Client.Teams.Create(&Team{
+				Name:      String("name"),
+				APIObject: APIObject{Type: String("team")},
+			})
+		})
+
+		It("should send and decode a wrapped team", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(team).To(Equal(&expectedTeam))
+		})
+	})
 })
@@ -1,6 +1,8 @@
 package pagerduty
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -13,37 +15,41 @@ type TimeZone struct {
 	*time.Location
 }
 
+// EncodeValues encodes the time zone under its PagerDuty name, or its IANA
+// name when PagerDuty has none for it.
 func (tz TimeZone) EncodeValues(key string, v *url.Values) error {
-	location := tz.Location
-	timeZone, ok := ianaToPagerDuty[location.String()]
-	if !ok {
-		return fmt.Errorf("time zone %q does not exist", location.String())
-	}
-
-	v.Add(key, timeZone)
+	v.Add(key, tz.name())
 	return nil
 }
 
+// MarshalJSON encodes the time zone under its PagerDuty name, or its IANA name
+// when PagerDuty has none for it. The clients speaking the REST API v2 send
+// IANA names only.
 func (tz *TimeZone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tz.name())
+}
+
+// name returns the PagerDuty name of the time zone, or its IANA name when
+// PagerDuty has none for it.
+func (tz TimeZone) name() string {
 	location := tz.Location.String()
-	timeZone, ok := ianaToPagerDuty[location]
-	if !ok {
-		return []byte{}, fmt.Errorf("time zone %q does not exist", location)
+	if timeZone, ok := ianaToPagerDuty[location]; ok {
+		return timeZone
 	}
-
-	return []byte(`"` + timeZone + `"`), nil
+	return location
 }
 
 func (tz *TimeZone) UnmarshalJSON(data []byte) error {
 	s := strings.Trim(string(data), `"`)
 	timeZone, ok := pagerdutyToIANA[s]
 	if !ok {
-		return fmt.Errorf("time zone %q does not exist", s)
+		// the REST API v2 uses IANA time zone names
+		timeZone = s
 	}
 
 	location, err := time.LoadLocation(timeZone)
 	if err != nil {
-		return err
+		return fmt.Errorf("time zone %q does not exist", s)
 	}
 
 	tz.Location = location
@@ -51,6 +57,77 @@ func (tz *TimeZone) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// ianaTimeZones returns path and body with the PagerDuty names of their
+// 'time_zone' query parameters and fields replaced by IANA names, which the
+// REST API v2 expects.
+func ianaTimeZones(path string, body interface{}) (string, interface{}, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	q := u.Query()
+	if names, ok := q["time_zone"]; ok {
+		for i, name := range names {
+			if timeZone, ok := pagerdutyToIANA[name]; ok {
+				names[i] = timeZone
+			}
+		}
+		u.RawQuery = q.Encode()
+		path = u.String()
+	}
+
+	if body == nil {
+		return path, nil, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", nil, err
+	}
+	if !replaceTimeZones(v) {
+		return path, body, nil
+	}
+
+	data, err = json.Marshal(v)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, json.RawMessage(data), nil
+}
+
+// replaceTimeZones replaces the PagerDuty names of the 'time_zone' fields of
+// the decoded JSON value v by IANA names, and reports whether it replaced any.
+func replaceTimeZones(v interface{}) bool {
+	var replaced bool
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if name, ok := e.(string); ok && k == "time_zone" {
+				if timeZone, ok := pagerdutyToIANA[name]; ok {
+					v[k] = timeZone
+					replaced = true
+				}
+				continue
+			}
+			replaced = replaceTimeZones(e) || replaced
+		}
+	case []interface{}:
+		for _, e := range v {
+			replaced = replaceTimeZones(e) || replaced
+		}
+	}
+	return replaced
+}
+
 // reverseMap is a helper function to reverse an existing map.
 func reverseMap(m map[string]string) map[string]string {
 	r := make(map[string]string, len(m))
@@ -0,0 +1,74 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var _ = Describe("TimeZone", func() {
+	var newYork, oslo *time.Location
+
+	BeforeEach(func() {
+		newYork, _ = time.LoadLocation("America/New_York")
+		oslo, _ = time.LoadLocation("Europe/Oslo")
+	})
+
+	Describe("encoding", func() {
+		It("should use the PagerDuty name of the time zone", func() {
+			data, err := json.Marshal(&User{TimeZone: &TimeZone{newYork}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{"time_zone": "Eastern Time (US & Canada)"}`))
+		})
+
+		It("should use the IANA name of the time zones without a PagerDuty name", func() {
+			data, err := json.Marshal(&User{TimeZone: &TimeZone{oslo}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{"time_zone": "Europe/Oslo"}`))
+
+			var user User
+			Expect(json.Unmarshal(data, &user)).To(Succeed())
+			Expect(user.TimeZone.Location).To(Equal(oslo))
+		})
+
+		It("should encode the time zones without a PagerDuty name as query parameters", func() {
+			v := url.Values{}
+			Expect(TimeZone{oslo}.EncodeValues("time_zone", &v)).To(Succeed())
+			Expect(v.Get("time_zone")).To(Equal("Europe/Oslo"))
+		})
+	})
+
+	Context("with the REST API v2", func() {
+		var env *TestEnvironment
+
+		BeforeEach(func() { env = NewTestEnvironmentV2() })
+		AfterEach(func() { env.Server.Close() })
+
+		It("should send the IANA name of the time zones", func() {
+			env.Server.RouteToHandler(POST, "/users", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"user": {"type": "user", "name": "name", "time_zone": "America/New_York"}}`),
+				ghttp.RespondWith(http.StatusCreated, userGetJSON),
+			))
+
+			_, _, err := env.Client.Users.Create(&User{Name: String("name"), TimeZone: &TimeZone{newYork}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should query the IANA name of the time zones", func() {
+			env.Server.RouteToHandler(GET, "/schedules/PI7DH85", ghttp.CombineHandlers(
+				ghttp.VerifyRequest(GET, "/schedules/PI7DH85", "time_zone=America%2FNew_York"),
+				ghttp.RespondWith(http.StatusOK, `{"schedule": {"id": "PI7DH85"}}`),
+			))
+
+			_, _, err := env.Client.Schedules.Entries("PI7DH85", &ScheduleEntriesOptions{TimeZone: &TimeZone{newYork}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -26,19 +27,29 @@ type User struct {
 	URL             *string   `json:"user_url,omitempty"`
 	AvatarURL       *string   `json:"avatar_url,omitempty"`
 	InvitationSent  *bool     `json:"invitation_sent,omitempty"`
-	MarketingOptOut *bool     `json:"marketing_opt_out,omitempt"`
+	MarketingOptOut *bool     `json:"marketing_opt_out,omitempty"`
 	JobTitle        *string   `json:"job_title,omitempty"`
+
+	// Only present when requested with IncludeContactMethod and
+	// IncludeNotificationRules.
+	ContactMethods    []ContactMethod    `json:"contact_methods,omitempty"`
+	NotificationRules []NotificationRule `json:"notification_rules,omitempty"`
+
+	APIObject
 }
 
 type UserListOptions struct {
 	// Filters the result, showing only the users whose names or email addresses
 	// match the query
-	Query string `url:"query,omitemtpy"`
+	Query string `url:"query,omitempty"`
 
 	// Array of additional details to include. This API accepts `contact_method`
 	// and `notification_rules`.
 	Include []string `url:"include,omitempty"`
 
+	// REST API v2 filter, showing only the users of the given teams.
+	TeamIDs []string `url:"team_ids[],omitempty"`
+
 	ListOptions
 }
 
@@ -50,13 +61,18 @@ type userListWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/list
 func (s *UsersService) List(opts *UserListOptions) ([]User, *Response, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext is the context aware variant of List.
+func (s *UsersService) ListContext(ctx context.Context, opts *UserListOptions) ([]User, *Response, error) {
 	uri, err := addOptions("users", opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	users := new(userListWrapper)
-	resp, err := s.client.Get(uri, users)
+	resp, err := s.client.GetContext(ctx, uri, users)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -64,6 +80,44 @@ func (s *UsersService) List(opts *UserListOptions) ([]User, *Response, error) {
 	return users.Users, resp, err
 }
 
+// ListAll fetches every user matching opts, following the pagination of List
+// until all of them or p.Max of them are collected.
+func (s *UsersService) ListAll(opts *UserListOptions, p *PaginationOptions) ([]User, error) {
+	return s.ListAllContext(context.Background(), opts, p)
+}
+
+// ListAllContext is the context aware variant of ListAll.
+func (s *UsersService) ListAllContext(ctx context.Context, opts *UserListOptions, p *PaginationOptions) ([]User, error) {
+	start, fetch := s.pager(opts)
+	return listAll(ctx, fetch, start, p)
+}
+
+// Iter returns an iterator that lazily walks every user matching opts.
+func (s *UsersService) Iter(opts *UserListOptions) *Iterator[User] {
+	return s.IterContext(context.Background(), opts)
+}
+
+// IterContext is the context aware variant of Iter.
+func (s *UsersService) IterContext(ctx context.Context, opts *UserListOptions) *Iterator[User] {
+	start, fetch := s.pager(opts)
+	return newIterator(ctx, fetch, start)
+}
+
+// pager returns the first page requested by opts and a function fetching any
+// page of the users matching opts.
+func (s *UsersService) pager(opts *UserListOptions) (ListOptions, pageFetcher[User]) {
+	var o UserListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return o.ListOptions, func(ctx context.Context, page ListOptions) ([]User, *Response, error) {
+		o := o
+		o.ListOptions = page
+		return s.ListContext(ctx, &o)
+	}
+}
+
 type GetUserOptions struct {
 	// Array of additional details to include. This API accepts `contact_method`
 	// and `notification_rules`.
@@ -78,6 +132,11 @@ type userWrapper struct {
 //
 // PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/show
 func (s *UsersService) Get(id string, opts *GetUserOptions) (*User, *Response, error) {
+	return s.GetContext(context.Background(), id, opts)
+}
+
+// GetContext is the context aware variant of Get.
+func (s *UsersService) GetContext(ctx context.Context, id string, opts *GetUserOptions) (*User, *Response, error) {
 	path := fmt.Sprintf("users/%s", id)
 	uri, err := addOptions(path, opts)
 	if err != nil {
@@ -85,10 +144,90 @@ func (s *UsersService) Get(id string, opts *GetUserOptions) (*User, *Response, e
 	}
 
 	user := new(userWrapper)
-	resp, err := s.client.Get(uri, user)
+	resp, err := s.client.GetContext(ctx, uri, user)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return user.User, resp, err
 }
+
+// body returns the request body creating or updating user. The REST API v1
+// receives the user unwrapped, and neither API takes its contact methods or
+// notification rules, which are managed on their own.
+func (s *UsersService) body(user *User) interface{} {
+	u := *user
+	u.ContactMethods = nil
+	u.NotificationRules = nil
+
+	if !s.client.isV2() {
+		return &u
+	}
+
+	if u.Type == nil {
+		u.Type = String(ObjectTypeUser)
+	}
+
+	return &userWrapper{User: &u}
+}
+
+// Create a user. The user is sent an invitation to the account.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/create
+func (s *UsersService) Create(user *User) (*User, *Response, error) {
+	return s.CreateContext(context.Background(), user)
+}
+
+// CreateContext is the context aware variant of Create.
+func (s *UsersService) CreateContext(ctx context.Context, user *User) (*User, *Response, error) {
+	if user == nil {
+		return nil, nil, fmt.Errorf("pagerduty: user cannot be nil")
+	}
+
+	uri := "users"
+
+	u := new(userWrapper)
+	resp, err := s.client.PostContext(ctx, uri, s.body(user), u)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return u.User, resp, err
+}
+
+// Update an existing user.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/update
+func (s *UsersService) Update(user *User) (*User, *Response, error) {
+	return s.UpdateContext(context.Background(), user)
+}
+
+// UpdateContext is the context aware variant of Update.
+func (s *UsersService) UpdateContext(ctx context.Context, user *User) (*User, *Response, error) {
+	if user == nil || user.ID == nil {
+		return nil, nil, fmt.Errorf("pagerduty: user id cannot be nil")
+	}
+
+	uri := fmt.Sprintf("users/%s", *user.ID)
+
+	u := new(userWrapper)
+	resp, err := s.client.PutContext(ctx, uri, s.body(user), u)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return u.User, resp, err
+}
+
+// Delete a user. Users who are the last target of an escalation rule, or who
+// have open incidents assigned, cannot be deleted.
+//
+// PagerDuty API docs: https://developer.pagerduty.com/documentation/rest/users/delete
+func (s *UsersService) Delete(id string) (*Response, error) {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is the context aware variant of Delete.
+func (s *UsersService) DeleteContext(ctx context.Context, id string) (*Response, error) {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("users/%s", id))
+}
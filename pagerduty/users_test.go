@@ -9,6 +9,7 @@ import (
 
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"regexp"
 )
 
@@ -26,6 +27,12 @@ const (
 		"user_url": "user_url",
 		"invitation_sent": false
 	}`
+	userIncludeJSON = `{ "user": {
+		"id": "PXPGF42",
+		"name": "Earline Greenholt",
+		"contact_methods": [` + contactMethodJSON + `],
+		"notification_rules": [` + notificationRuleJSON + `]
+	}}`
 )
 
 var _ = Describe("Users", func() {
@@ -74,6 +81,26 @@ var _ = Describe("Users", func() {
 				Expect(users[0]).To(Equal(expectedUser))
 			})
 		})
+
+		Context("with empty options", func() {
+			It("should not send the unset query", func() {
+				env.Server.RouteToHandler(GET, "/users", ghttp.CombineHandlers(
+					ghttp.VerifyRequest(GET, "/users", ""),
+					ghttp.RespondWith(http.StatusOK, userListJSON),
+				))
+
+				_, _, err := env.Client.Users.List(&UserListOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("encoding", func() {
+		It("should leave out an unset marketing opt out", func() {
+			data, err := json.Marshal(&User{Name: String("name")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring("marketing_opt_out"))
+		})
 	})
 
 	Describe("Get", func() {
@@ -112,4 +139,161 @@ var _ = Describe("Users", func() {
 			})
 		})
 	})
+
+	Describe("Get with contact methods and notification rules", func() {
+		It("should decode the included contact methods and notification rules", func() {
+			var expectedContactMethod ContactMethod
+			var expectedNotificationRule NotificationRule
+			json.Unmarshal([]byte(contactMethodJSON), &expectedContactMethod)
+			json.Unmarshal([]byte(notificationRuleJSON), &expectedNotificationRule)
+
+			values := make(url.Values)
+			values.Add("include", IncludeContactMethod)
+			values.Add("include", IncludeNotificationRules)
+
+			env.Server.RouteToHandler(GET, "/users/PXPGF42", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				verifyURLQueryHandler(values),
+				ghttp.RespondWith(http.StatusOK, userIncludeJSON),
+			))
+
+			user, _, err := env.Client.Users.Get("PXPGF42", &GetUserOptions{
+				Include: []string{IncludeContactMethod, IncludeNotificationRules},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.ContactMethods).To(Equal([]ContactMethod{expectedContactMethod}))
+			Expect(user.NotificationRules).To(Equal([]NotificationRule{expectedNotificationRule}))
+			Expect(*user.NotificationRules[0].ContactMethod.Address).To(Equal("betty@example.com"))
+		})
+	})
+
+	Describe("Create", func() {
+		var (
+			user *User
+			resp *Response
+			err  error
+		)
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(POST, "/users", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					verifyContentHeaderHandler,
+					ghttp.VerifyJSON(`{
+						"name": "name",
+						"email": "email",
+						"role": "role"
+					}`),
+					ghttp.RespondWith(http.StatusCreated, userGetJSON),
+				))
+
+				user, resp, err = env.Client.Users.Create(&User{
+					Name:           String("name"),
+					Email:          String("email"),
+					Role:           String("role"),
+					ContactMethods: []ContactMethod{{ID: String("PDBIBUJ")}},
+				})
+			})
+
+			It("should have made a request", func() {
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return a non-empty response", func() {
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return the created user", func() {
+				Expect(user).To(Equal(&expectedUser))
+			})
+		})
+
+		Context("with the REST API v2", func() {
+			BeforeEach(func() {
+				env.Server.Close()
+				env = NewTestEnvironmentV2()
+
+				env.Server.RouteToHandler(POST, "/users", ghttp.CombineHandlers(
+					verifyV2HeaderHandler,
+					ghttp.VerifyJSON(`{
+						"user": {
+							"type": "user",
+							"name": "name",
+							"email": "email"
+						}
+					}`),
+					ghttp.RespondWith(http.StatusCreated, userGetJSON),
+				))
+
+				user, resp, err = env.Client.Users.Create(&User{
+					Name:  String("name"),
+					Email: String("email"),
+				})
+			})
+
+			It("should send the wrapped user", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user).To(Equal(&expectedUser))
+			})
+		})
+
+		Context("without a user", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Users.Create(nil)
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		var (
+			user *User
+			err  error
+		)
+
+		Context("with a successful, non-empty response", func() {
+			BeforeEach(func() {
+				env.Server.RouteToHandler(PUT, "/users/id", ghttp.CombineHandlers(
+					verifyHeaderHandler,
+					ghttp.VerifyJSON(`{ "id": "id", "job_title": "SRE" }`),
+					ghttp.RespondWith(http.StatusOK, userGetJSON),
+				))
+
+				user, _, err = env.Client.Users.Update(&User{ID: String("id"), JobTitle: String("SRE")})
+			})
+
+			It("should return the updated user", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+				Expect(user).To(Equal(&expectedUser))
+			})
+		})
+
+		Context("without an id", func() {
+			It("should return an error without making a request", func() {
+				_, _, err = env.Client.Users.Update(&User{Name: String("name")})
+				Expect(err).To(HaveOccurred())
+				Expect(env.Server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete the user", func() {
+			env.Server.RouteToHandler(DELETE, "/users/id", ghttp.CombineHandlers(
+				verifyHeaderHandler,
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			_, err := env.Client.Users.Delete("id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
 })
@@ -0,0 +1,172 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WebhookHandlerFunc handles a single message received from the PagerDuty
+// Webhooks API. ctx is the context of the HTTP request that delivered the
+// message.
+type WebhookHandlerFunc func(ctx context.Context, message *WebhookMessage) error
+
+// WebhookHandlerError reports a message that one of the registered callbacks
+// failed to handle.
+type WebhookHandlerError struct {
+	MessageID   string
+	MessageType string
+	Err         error
+}
+
+func (e *WebhookHandlerError) Error() string {
+	return fmt.Sprintf("pagerduty: handling webhook message %s (%s): %v", e.MessageID, e.MessageType, e.Err)
+}
+
+// WebhookHandlerErrors is the list of errors returned by the callbacks while
+// handling a batch of messages.
+type WebhookHandlerErrors []*WebhookHandlerError
+
+func (e WebhookHandlerErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// WebhookHandler is an http.Handler that decodes the batches of messages sent
+// by the PagerDuty Webhooks API and dispatches every message to the callbacks
+// registered for its type. Callbacks must be registered before the handler
+// starts serving requests.
+//
+// The handler responds with:
+//   - 405 Method Not Allowed to requests that are not POST requests,
+//   - 400 Bad Request when the body cannot be decoded,
+//   - 500 Internal Server Error when any callback returned an error, so that
+//     PagerDuty delivers the batch again,
+//   - 204 No Content otherwise.
+type WebhookHandler struct {
+	handlers map[string][]WebhookHandlerFunc
+
+	// ErrorHandler, if set, is called with the errors returned by the
+	// callbacks while handling the messages of a request.
+	ErrorHandler func(r *http.Request, errs WebhookHandlerErrors)
+}
+
+// NewWebhookHandler returns a new WebhookHandler with no registered callbacks.
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{handlers: make(map[string][]WebhookHandlerFunc)}
+}
+
+// On registers fn to be called for every message of the given type (e.g.
+// WebhookIncidentTrigger). Several callbacks can be registered for the same
+// type, they are called in registration order.
+func (h *WebhookHandler) On(messageType string, fn WebhookHandlerFunc) {
+	h.handlers[messageType] = append(h.handlers[messageType], fn)
+}
+
+// OnAcknowledge registers fn for 'incident.acknowledge' messages.
+func (h *WebhookHandler) OnAcknowledge(fn WebhookHandlerFunc) {
+	h.On(WebhookIncidentAcknowledge, fn)
+}
+
+// OnAssign registers fn for 'incident.assign' messages.
+func (h *WebhookHandler) OnAssign(fn WebhookHandlerFunc) {
+	h.On(WebhookIncidentAssign, fn)
+}
+
+// OnDelegate registers fn for 'incident.delegate' messages.
+func (h *WebhookHandler) OnDelegate(fn WebhookHandlerFunc) {
+	h.On(WebhookIncidentDelegate, fn)
+}
+
+// OnEscalate registers fn for 'incident.escalate' messages.
+func (h *WebhookHandler) OnEscalate(fn WebhookHandlerFunc) {
+	h.On(WebhookIncidentEscalate, fn)
+}
+
+// OnResolve registers fn for 'incident.resolve' messages.
+func (h *WebhookHandler) OnResolve(fn WebhookHandlerFunc) {
+	h.On(WebhookIncidentResolve, fn)
+}
+
+// OnTrigger registers fn for 'incident.trigger' messages.
+func (h *WebhookHandler) OnTrigger(fn WebhookHandlerFunc) {
+	h.On(WebhookIncidentTrigger, fn)
+}
+
+// OnUnacknowledge registers fn for 'incident.unacknowledge' messages.
+func (h *WebhookHandler) OnUnacknowledge(fn WebhookHandlerFunc) {
+	h.On(WebhookIncidentUnacknowledge, fn)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != POST {
+		w.Header().Set("Allow", POST)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	messages, err := decodeWebhookMessages(r.Body)
+	if err != nil {
+		http.Error(w, "invalid webhook payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	errs := h.dispatch(r.Context(), messages)
+	if len(errs) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if h.ErrorHandler != nil {
+		h.ErrorHandler(r, errs)
+	}
+
+	type messageError struct {
+		ID    string `json:"id"`
+		Type  string `json:"type"`
+		Error string `json:"error"`
+	}
+
+	body := struct {
+		Errors []messageError `json:"errors"`
+	}{}
+	for _, e := range errs {
+		body.Errors = append(body.Errors, messageError{e.MessageID, e.MessageType, e.Err.Error()})
+	}
+
+	w.Header().Set(headerContentType, contentType)
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(body)
+}
+
+// dispatch calls the registered callbacks for every message and collects
+// their errors. Messages without callbacks are ignored.
+func (h *WebhookHandler) dispatch(ctx context.Context, messages []WebhookMessage) WebhookHandlerErrors {
+	var errs WebhookHandlerErrors
+	for i := range messages {
+		message := &messages[i]
+
+		var id, messageType string
+		if message.ID != nil {
+			id = *message.ID
+		}
+		if message.Type != nil {
+			messageType = *message.Type
+		}
+
+		for _, fn := range h.handlers[messageType] {
+			if err := fn(ctx, message); err != nil {
+				errs = append(errs, &WebhookHandlerError{id, messageType, err})
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,113 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+var _ = Describe("WebhookHandler", func() {
+	var (
+		handler  *WebhookHandler
+		recorder *httptest.ResponseRecorder
+		method   string
+		body     string
+
+		triggered []WebhookMessage
+		resolved  []WebhookMessage
+	)
+
+	BeforeEach(func() {
+		triggered, resolved = nil, nil
+		method, body = POST, webhookJSON
+
+		handler = NewWebhookHandler()
+		handler.OnTrigger(func(ctx context.Context, message *WebhookMessage) error {
+			triggered = append(triggered, *message)
+			return nil
+		})
+		handler.OnResolve(func(ctx context.Context, message *WebhookMessage) error {
+			resolved = append(resolved, *message)
+			return nil
+		})
+	})
+
+	JustBeforeEach(func() {
+		recorder = httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/webhooks", strings.NewReader(body))
+		handler.ServeHTTP(recorder, req)
+	})
+
+	Context("with a valid batch of messages", func() {
+		It("should respond with no content", func() {
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should dispatch the messages to the callbacks of their type", func() {
+			Expect(triggered).To(HaveLen(1))
+			Expect(*triggered[0].ID).To(Equal("bb8b8fe0-e8d5-11e2-9c1e-22000afd16cf"))
+			Expect(resolved).To(BeEmpty())
+		})
+	})
+
+	Context("with a request that is not a POST request", func() {
+		BeforeEach(func() { method = GET })
+
+		It("should respond with method not allowed", func() {
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(recorder.Header().Get("Allow")).To(Equal(POST))
+			Expect(triggered).To(BeEmpty())
+		})
+	})
+
+	Context("with an invalid body", func() {
+		BeforeEach(func() { body = `{ "messages": ` })
+
+		It("should respond with bad request", func() {
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when a callback fails", func() {
+		var reported WebhookHandlerErrors
+
+		BeforeEach(func() {
+			reported = nil
+			handler.OnTrigger(func(ctx context.Context, message *WebhookMessage) error {
+				return errors.New("boom")
+			})
+			handler.ErrorHandler = func(r *http.Request, errs WebhookHandlerErrors) {
+				reported = errs
+			}
+		})
+
+		It("should still call the other callbacks", func() {
+			Expect(triggered).To(HaveLen(1))
+		})
+
+		It("should respond with an internal server error", func() {
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			Expect(recorder.Body.String()).To(MatchJSON(`{
+				"errors": [{
+					"id": "bb8b8fe0-e8d5-11e2-9c1e-22000afd16cf",
+					"type": "incident.trigger",
+					"error": "boom"
+				}]
+			}`))
+		})
+
+		It("should report the error of the message", func() {
+			Expect(reported).To(HaveLen(1))
+			Expect(reported[0].MessageID).To(Equal("bb8b8fe0-e8d5-11e2-9c1e-22000afd16cf"))
+			Expect(reported[0].MessageType).To(Equal(WebhookIncidentTrigger))
+			Expect(reported[0].Err).To(MatchError("boom"))
+		})
+	})
+})
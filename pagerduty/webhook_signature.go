@@ -0,0 +1,138 @@
+package pagerduty
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	// HeaderWebhookSignature is the header carrying the signatures of a
+	// webhook payload.
+	HeaderWebhookSignature = "X-PagerDuty-Signature"
+
+	webhookSignatureVersion = "v1="
+
+	// MaxWebhookBodySize is the size of the largest webhook payload read to
+	// verify its signature, 1 MiB. Larger payloads are rejected unread.
+	MaxWebhookBodySize = 1 << 20
+)
+
+var (
+	// ErrWebhookNoSecret is returned when a signature is verified without any
+	// secret to verify it against.
+	ErrWebhookNoSecret = errors.New("pagerduty: no webhook secret configured")
+
+	// ErrWebhookSignatureMissing is returned when a webhook payload carries no
+	// signature of a supported version.
+	ErrWebhookSignatureMissing = errors.New("pagerduty: webhook signature missing")
+
+	// ErrWebhookSignatureMismatch is returned when none of the signatures of a
+	// webhook payload match any of the secrets.
+	ErrWebhookSignatureMismatch = errors.New("pagerduty: webhook signature mismatch")
+
+	// ErrWebhookBodyTooLarge is returned when a webhook payload is larger than
+	// MaxWebhookBodySize.
+	ErrWebhookBodyTooLarge = errors.New("pagerduty: webhook payload too large")
+)
+
+// VerifyWebhookSignature verifies that body was signed by PagerDuty with one
+// of the given secrets. header is the value of the 'X-PagerDuty-Signature'
+// header, a comma-separated list of signatures such as 'v1=<hex>'. PagerDuty
+// sends one signature per active secret while a secret is being rotated, and
+// several secrets can be passed to accept both the old and the new one.
+//
+// It returns nil if any signature matches any secret, ErrWebhookNoSecret,
+// ErrWebhookSignatureMissing or ErrWebhookSignatureMismatch otherwise.
+func VerifyWebhookSignature(body []byte, header string, secrets ...string) error {
+	if len(secrets) == 0 {
+		return ErrWebhookNoSecret
+	}
+
+	var signatures [][]byte
+	for _, s := range strings.Split(header, ",") {
+		s = strings.TrimSpace(s)
+		if !strings.HasPrefix(s, webhookSignatureVersion) {
+			continue
+		}
+
+		sig, err := hex.DecodeString(strings.TrimPrefix(s, webhookSignatureVersion))
+		if err != nil {
+			continue
+		}
+		signatures = append(signatures, sig)
+	}
+
+	if len(signatures) == 0 {
+		return ErrWebhookSignatureMissing
+	}
+
+	for _, secret := range secrets {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		expected := mac.Sum(nil)
+
+		for _, sig := range signatures {
+			if hmac.Equal(sig, expected) {
+				return nil
+			}
+		}
+	}
+
+	return ErrWebhookSignatureMismatch
+}
+
+// verifyWebhookRequest reads and verifies the body of r, up to
+// MaxWebhookBodySize, and replaces it so that it can be read again. w may be
+// nil when there is no response to write.
+func verifyWebhookRequest(w http.ResponseWriter, r *http.Request, secrets []string) error {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxWebhookBodySize))
+	r.Body.Close()
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return ErrWebhookBodyTooLarge
+	}
+	if err != nil {
+		return err
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return VerifyWebhookSignature(body, r.Header.Get(HeaderWebhookSignature), secrets...)
+}
+
+// VerifyWebhookSignatureHandler returns an http.Handler that only passes the
+// requests signed with one of the given secrets on to next, such as a
+// WebhookHandler. Other requests are answered with 401 Unauthorized, or 413
+// Request Entity Too Large when larger than MaxWebhookBodySize.
+func VerifyWebhookSignatureHandler(next http.Handler, secrets ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := verifyWebhookRequest(w, r, secrets)
+		switch err {
+		case nil:
+			next.ServeHTTP(w, r)
+		case ErrWebhookSignatureMissing, ErrWebhookSignatureMismatch:
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case ErrWebhookBodyTooLarge:
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// DecodeVerifiedMessages verifies the signature of a webhook request against
+// the given secrets and decodes its messages. Payloads larger than
+// MaxWebhookBodySize are rejected with ErrWebhookBodyTooLarge.
+func (s *WebhooksService) DecodeVerifiedMessages(r *http.Request, secrets ...string) ([]WebhookMessage, error) {
+	if err := verifyWebhookRequest(nil, r, secrets); err != nil {
+		return nil, err
+	}
+
+	return decodeWebhookMessages(r.Body)
+}
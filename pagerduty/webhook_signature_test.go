@@ -0,0 +1,187 @@
+package pagerduty_test
+
+import (
+	. "github.com/hudl/go-pagerduty/pagerduty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// signWebhook returns the 'v1' signature of body with secret.
+func signWebhook(body, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("Webhook signatures", func() {
+	const (
+		secret    = "secret"
+		oldSecret = "old-secret"
+	)
+
+	Describe("VerifyWebhookSignature", func() {
+		var (
+			header  string
+			secrets []string
+			err     error
+		)
+
+		BeforeEach(func() {
+			header = signWebhook(webhookJSON, secret)
+			secrets = []string{secret}
+		})
+
+		JustBeforeEach(func() {
+			err = VerifyWebhookSignature([]byte(webhookJSON), header, secrets...)
+		})
+
+		Context("with a valid signature", func() {
+			It("should not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("with several signatures during a secret rotation", func() {
+			BeforeEach(func() {
+				header = signWebhook(webhookJSON, "unknown") + ", " + signWebhook(webhookJSON, oldSecret)
+				secrets = []string{secret, oldSecret}
+			})
+
+			It("should accept any matching signature", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("with a signature made with another secret", func() {
+			BeforeEach(func() { header = signWebhook(webhookJSON, "unknown") })
+
+			It("should return a mismatch error", func() {
+				Expect(err).To(Equal(ErrWebhookSignatureMismatch))
+			})
+		})
+
+		Context("with a tampered body", func() {
+			BeforeEach(func() { header = signWebhook(webhookJSON+" ", secret) })
+
+			It("should return a mismatch error", func() {
+				Expect(err).To(Equal(ErrWebhookSignatureMismatch))
+			})
+		})
+
+		Context("without a signature", func() {
+			BeforeEach(func() { header = "" })
+
+			It("should return a missing signature error", func() {
+				Expect(err).To(Equal(ErrWebhookSignatureMissing))
+			})
+		})
+
+		Context("with signatures of an unsupported version", func() {
+			BeforeEach(func() { header = strings.Replace(header, "v1=", "v0=", 1) })
+
+			It("should return a missing signature error", func() {
+				Expect(err).To(Equal(ErrWebhookSignatureMissing))
+			})
+		})
+
+		Context("without any secret", func() {
+			BeforeEach(func() { secrets = nil })
+
+			It("should return a no secret error", func() {
+				Expect(err).To(Equal(ErrWebhookNoSecret))
+			})
+		})
+	})
+
+	Describe("VerifyWebhookSignatureHandler", func() {
+		var (
+			recorder *httptest.ResponseRecorder
+			body     string
+			header   string
+			messages []WebhookMessage
+		)
+
+		BeforeEach(func() {
+			messages = nil
+			body = webhookJSON
+			header = signWebhook(webhookJSON, secret)
+		})
+
+		JustBeforeEach(func() {
+			handler := NewWebhookHandler()
+			handler.On(WebhookIncidentTrigger, func(ctx context.Context, message *WebhookMessage) error {
+				messages = append(messages, *message)
+				return nil
+			})
+
+			req := httptest.NewRequest(POST, "/webhooks", strings.NewReader(body))
+			req.Header.Set("X-PagerDuty-Signature", header)
+
+			recorder = httptest.NewRecorder()
+			VerifyWebhookSignatureHandler(handler, secret).ServeHTTP(recorder, req)
+		})
+
+		Context("with a valid signature", func() {
+			It("should pass the request on", func() {
+				Expect(recorder.Code).To(Equal(http.StatusNoContent))
+				Expect(messages).To(HaveLen(1))
+			})
+		})
+
+		Context("with an invalid signature", func() {
+			BeforeEach(func() { header = signWebhook(webhookJSON, "unknown") })
+
+			It("should reject the request", func() {
+				Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+				Expect(messages).To(BeEmpty())
+			})
+		})
+
+		Context("with a payload larger than the maximum", func() {
+			BeforeEach(func() {
+				body = webhookJSON + strings.Repeat(" ", MaxWebhookBodySize)
+				header = signWebhook(body, secret)
+			})
+
+			It("should reject the request", func() {
+				Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge))
+				Expect(messages).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DecodeVerifiedMessages", func() {
+		It("should decode the messages of a signed request", func() {
+			env := NewTestEnvironment()
+			defer env.Server.Close()
+
+			req := httptest.NewRequest(POST, "/webhooks", strings.NewReader(webhookJSON))
+			req.Header.Set("X-PagerDuty-Signature", signWebhook(webhookJSON, secret))
+
+			messages, err := env.Client.Webhooks.DecodeVerifiedMessages(req, secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messages).To(HaveLen(1))
+		})
+
+		It("should reject payloads larger than the maximum", func() {
+			env := NewTestEnvironment()
+			defer env.Server.Close()
+
+			body := webhookJSON + strings.Repeat(" ", MaxWebhookBodySize)
+			req := httptest.NewRequest(POST, "/webhooks", strings.NewReader(body))
+			req.Header.Set("X-PagerDuty-Signature", signWebhook(body, secret))
+
+			_, err := env.Client.Webhooks.DecodeVerifiedMessages(req, secret)
+			Expect(err).To(Equal(ErrWebhookBodyTooLarge))
+		})
+	})
+})
@@ -68,7 +68,13 @@ type webhookMessageListWrapper struct {
 	Messages []WebhookMessage `json:"messages"`
 }
 
+// DecodeMessages decodes a batch of messages sent by the PagerDuty Webhooks
+// API.
 func (s *WebhooksService) DecodeMessages(reader io.Reader) ([]WebhookMessage, error) {
+	return decodeWebhookMessages(reader)
+}
+
+func decodeWebhookMessages(reader io.Reader) ([]WebhookMessage, error) {
 	messages := new(webhookMessageListWrapper)
 	err := json.NewDecoder(reader).Decode(messages)
 	if err != nil {